@@ -0,0 +1,352 @@
+package mask
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/anu1097/golang-masking-tool/customMasker"
+	"github.com/anu1097/golang-masking-tool/filter"
+)
+
+var (
+	// ErrInvalidRecords is returned when anonymizing a value which isn't a slice or an array of structs
+	ErrInvalidRecords = errors.New("records must be a slice or an array of structs")
+
+	// ErrAnonymityUnreachable is returned when the target k or l can't be met within the suppression limit, even with
+	// every quasi-identifier suppressed
+	ErrAnonymityUnreachable = errors.New("target anonymity can't be reached")
+)
+
+// suppressedValue replaces the string values of quasi-identifiers generalized beyond their hierarchy
+const suppressedValue = "*"
+
+// QuasiIdentifier is a column of quasi-identifiers, like a postal code or a date of birth, and its generalization
+// hierarchy
+type QuasiIdentifier struct {
+	// Filter selects the fields of the column, e.g. filter.FieldFilter("PostalCode") or filter.TagFilter("qi")
+	Filter filter.Filter
+	// Hierarchy lists generalizers from the finest to the coarsest. Values are suppressed beyond the last level:
+	// strings are replaced with "*" and typed values with empty values.
+	Hierarchy []customMasker.Generalizer
+}
+
+// Anonymizer checks and enforces the k-anonymity of datasets: each combination of quasi-identifiers is shared by at
+// least K records, and optionally the l-diversity of a sensitive field: each combination has at least L distinct
+// sensitive values.
+type Anonymizer struct {
+	QuasiIdentifiers []QuasiIdentifier
+	// Sensitive selects the sensitive field checked for l-diversity, e.g. filter.FieldFilter("Diagnosis")
+	Sensitive filter.Filter
+	// K is the target size of the smallest group of records sharing their quasi-identifiers
+	K int
+	// L is the target number of distinct sensitive values of each group, ignored without Sensitive
+	L int
+	// MaxSuppression is the fraction of records which may be removed instead of generalizing the quasi-identifiers of
+	// all records further, e.g. 0.05 for at most 5% of the records
+	MaxSuppression float64
+}
+
+// AnonymityReport describes the anonymity of a dataset
+type AnonymityReport struct {
+	// Records is the number of records, after suppression
+	Records int
+	// K is the size of the smallest group of records sharing their quasi-identifiers, 0 without records
+	K int
+	// L is the number of distinct sensitive values of the least diverse group, 0 without sensitive field
+	L int
+	// Groups is the number of distinct combinations of quasi-identifiers
+	Groups int
+	// Suppressed is the number of records removed by Anonymize
+	Suppressed int
+	// Levels is the generalization level of each quasi-identifier applied by Anonymize, 0 for the original values
+	Levels []int
+}
+
+// Check reports the k-anonymity and l-diversity of records, a slice or an array of structs or pointers to structs
+//
+// Example:
+//
+//	anonymizer := mask.Anonymizer{
+//		QuasiIdentifiers: []mask.QuasiIdentifier{{Filter: filter.FieldFilter("Age")}, {Filter: filter.FieldFilter("PostalCode")}},
+//		Sensitive:        filter.FieldFilter("Diagnosis"),
+//	}
+//	report, err := anonymizer.Check(patients)
+func (a Anonymizer) Check(records interface{}) (AnonymityReport, error) {
+	table, err := a.newAnonymityTable(records)
+	if err != nil {
+		return AnonymityReport{}, err
+	}
+	levels := make([]int, len(a.QuasiIdentifiers))
+	return table.report(table.groups(levels), levels, nil), nil
+}
+
+// Anonymize returns a copy of records whose quasi-identifiers are generalized, and whose records of too small or too
+// little diverse groups are suppressed, until the target K and L are met. Quasi-identifiers are generalized one level
+// at a time for all records, choosing the quasi-identifier which leaves the fewest records in violation, and the
+// records still in violation are suppressed once there are few enough of them. The copy is a slice of the elements of
+// records, which keep their order, and deep copies them like MaskDetails: it shares no slice, map or pointer with
+// records, except through unexported fields. Nil pointers are kept as nil, and aren't counted as records. It returns
+// ErrAnonymityUnreachable if the targets can't be met.
+func (a Anonymizer) Anonymize(records interface{}) (interface{}, AnonymityReport, error) {
+	table, err := a.newAnonymityTable(records)
+	if err != nil {
+		return nil, AnonymityReport{}, err
+	}
+	maxSuppressed := int(a.MaxSuppression * float64(len(table.rows)))
+	levels := make([]int, len(a.QuasiIdentifiers))
+	for {
+		groups := table.groups(levels)
+		violating := table.violating(groups)
+		if len(violating) <= maxSuppressed {
+			suppressed := map[int]bool{}
+			for _, row := range violating {
+				suppressed[row] = true
+			}
+			return table.output(levels, suppressed), table.report(groups, levels, suppressed), nil
+		}
+		if !table.canGeneralize(levels) {
+			return nil, table.report(groups, levels, nil), fmt.Errorf("%w: k=%d l=%d", ErrAnonymityUnreachable, a.K, a.L)
+		}
+
+		// generalize the quasi-identifier leaving the fewest records in violation
+		best, bestViolating := -1, 0
+		for qi := range levels {
+			if !table.canGeneralizeColumn(qi, levels) {
+				continue
+			}
+			levels[qi]++
+			if count := len(table.violating(table.groups(levels))); best < 0 || count < bestViolating {
+				best, bestViolating = qi, count
+			}
+			levels[qi]--
+		}
+		levels[best]++
+	}
+}
+
+// anonymityTable holds the quasi-identifiers and the sensitive values of records
+type anonymityTable struct {
+	anonymizer Anonymizer
+	records    reflect.Value
+	// rows holds the records which aren't nil pointers, and indexes their position in records
+	rows    []reflect.Value
+	indexes []int
+	// columns holds the indexes of the fields of each quasi-identifier
+	columns   [][]int
+	sensitive []int
+}
+
+func (a Anonymizer) newAnonymityTable(records interface{}) (*anonymityTable, error) {
+	value := reflect.ValueOf(records)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, ErrInvalidRecords
+	}
+	elemType := value.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, ErrInvalidRecords
+	}
+
+	table := &anonymityTable{anonymizer: a, records: value, columns: make([][]int, len(a.QuasiIdentifiers))}
+	for i := 0; i < value.Len(); i++ {
+		row := value.Index(i)
+		if row.Kind() == reflect.Ptr {
+			if row.IsNil() {
+				continue
+			}
+			row = row.Elem()
+		}
+		table.rows = append(table.rows, row)
+		table.indexes = append(table.indexes, i)
+	}
+
+	// columns are selected with the filters, by field name, value and tag, as when masking. Filters may depend on the
+	// values, so a field belongs to a column if the filter selects it in any record.
+	samples := table.rows
+	if len(samples) == 0 {
+		samples = []reflect.Value{reflect.New(structType).Elem()}
+	}
+	selects := func(f filter.Filter, field reflect.StructField, index int, tag string) bool {
+		if f == nil {
+			return false
+		}
+		for _, row := range samples {
+			if f.ShouldMask(field.Name, row.Field(index).Interface(), tag) {
+				return true
+			}
+		}
+		return false
+	}
+	for field := 0; field < structType.NumField(); field++ {
+		f := structType.Field(field)
+		if f.PkgPath != "" {
+			continue
+		}
+		tag := f.Tag.Get(filter.GetTagKey())
+		for qi, q := range a.QuasiIdentifiers {
+			if selects(q.Filter, f, field, tag) {
+				table.columns[qi] = append(table.columns[qi], field)
+				break
+			}
+		}
+		if selects(a.Sensitive, f, field, tag) {
+			table.sensitive = append(table.sensitive, field)
+		}
+	}
+	return table, nil
+}
+
+// generalized returns the value of a field generalized to the level of its quasi-identifier
+func (t *anonymityTable) generalized(qi int, level int, value reflect.Value) reflect.Value {
+	if level == 0 {
+		return value
+	}
+	hierarchy := t.anonymizer.QuasiIdentifiers[qi].Hierarchy
+	if level <= len(hierarchy) {
+		g := hierarchy[level-1]
+		if value.Kind() == reflect.String {
+			if generalized, ok := g.GeneralizeString(value.String()); ok {
+				return reflect.ValueOf(generalized).Convert(value.Type())
+			}
+		} else if generalized, ok := g.GeneralizeValue(value.Interface()); ok {
+			if v := reflect.ValueOf(generalized); v.IsValid() && v.Type().ConvertibleTo(value.Type()) {
+				return v.Convert(value.Type())
+			}
+		}
+	}
+	if value.Kind() == reflect.String {
+		return reflect.ValueOf(suppressedValue).Convert(value.Type())
+	}
+	return reflect.Zero(value.Type())
+}
+
+// groups returns the rows of each combination of generalized quasi-identifiers
+func (t *anonymityTable) groups(levels []int) map[string][]int {
+	groups := map[string][]int{}
+	for row, record := range t.rows {
+		var key strings.Builder
+		for qi, fields := range t.columns {
+			for _, field := range fields {
+				fmt.Fprintf(&key, "%#v\x00", t.generalized(qi, levels[qi], record.Field(field)).Interface())
+			}
+		}
+		groups[key.String()] = append(groups[key.String()], row)
+	}
+	return groups
+}
+
+// diversity returns the number of distinct sensitive values of the rows
+func (t *anonymityTable) diversity(rows []int) int {
+	values := map[string]bool{}
+	for _, row := range rows {
+		var key strings.Builder
+		for _, field := range t.sensitive {
+			fmt.Fprintf(&key, "%#v\x00", t.rows[row].Field(field).Interface())
+		}
+		values[key.String()] = true
+	}
+	return len(values)
+}
+
+// violating returns the rows of the groups which are too small or too little diverse
+func (t *anonymityTable) violating(groups map[string][]int) []int {
+	var rows []int
+	for _, group := range groups {
+		if len(group) < t.anonymizer.K || t.checksDiversity() && t.diversity(group) < t.anonymizer.L {
+			rows = append(rows, group...)
+		}
+	}
+	return rows
+}
+
+func (t *anonymityTable) checksDiversity() bool {
+	return t.anonymizer.Sensitive != nil && t.anonymizer.L > 0
+}
+
+// canGeneralize reports whether a quasi-identifier isn't suppressed yet
+func (t *anonymityTable) canGeneralize(levels []int) bool {
+	for qi := range levels {
+		if t.canGeneralizeColumn(qi, levels) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *anonymityTable) canGeneralizeColumn(qi int, levels []int) bool {
+	return len(t.columns[qi]) > 0 && levels[qi] <= len(t.anonymizer.QuasiIdentifiers[qi].Hierarchy)
+}
+
+func (t *anonymityTable) report(groups map[string][]int, levels []int, suppressed map[int]bool) AnonymityReport {
+	report := AnonymityReport{Suppressed: len(suppressed), Levels: append([]int(nil), levels...)}
+	for _, group := range groups {
+		var kept []int
+		for _, row := range group {
+			if !suppressed[row] {
+				kept = append(kept, row)
+			}
+		}
+		if len(kept) == 0 {
+			continue
+		}
+		report.Groups++
+		report.Records += len(kept)
+		if report.K == 0 || len(kept) < report.K {
+			report.K = len(kept)
+		}
+		if len(t.sensitive) > 0 {
+			if l := t.diversity(kept); report.L == 0 || l < report.L {
+				report.L = l
+			}
+		}
+	}
+	return report
+}
+
+// output deep copies the records which aren't suppressed with their quasi-identifiers generalized, and keeps nil
+// pointers
+func (t *anonymityTable) output(levels []int, suppressed map[int]bool) interface{} {
+	sliceType := t.records.Type()
+	if sliceType.Kind() == reflect.Array {
+		sliceType = reflect.SliceOf(sliceType.Elem())
+	}
+	out := reflect.MakeSlice(sliceType, 0, t.records.Len()-len(suppressed))
+	isPtr := sliceType.Elem().Kind() == reflect.Ptr
+	copier := &masking{}
+	row := 0
+	for i := 0; i < t.records.Len(); i++ {
+		if row == len(t.rows) || t.indexes[row] != i {
+			out = reflect.Append(out, reflect.Zero(sliceType.Elem()))
+			continue
+		}
+		record, suppress := t.rows[row], suppressed[row]
+		row++
+		if suppress {
+			continue
+		}
+		// unexported fields are copied as is, and exported ones are cloned without filters
+		copied := reflect.New(record.Type()).Elem()
+		copied.Set(record)
+		for field := 0; field < record.NumField(); field++ {
+			if record.Field(field).CanInterface() {
+				copied.Field(field).Set(copier.clone("", record.Field(field), "", nil))
+			}
+		}
+		for qi, fields := range t.columns {
+			for _, field := range fields {
+				copied.Field(field).Set(t.generalized(qi, levels[qi], record.Field(field)))
+			}
+		}
+		if isPtr {
+			out = reflect.Append(out, copied.Addr())
+		} else {
+			out = reflect.Append(out, copied)
+		}
+	}
+	return out.Interface()
+}
@@ -0,0 +1,294 @@
+package customMasker
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// AddressComponent is a part of a postal address
+type AddressComponent string
+
+// Components of a postal address
+const (
+	AHouseNumber AddressComponent = "house_number"
+	AStreet      AddressComponent = "street"
+	AUnit        AddressComponent = "unit"
+	ACity        AddressComponent = "city"
+	ARegion      AddressComponent = "region"
+	APostalCode  AddressComponent = "postal_code"
+	ACountry     AddressComponent = "country"
+)
+
+// AddressAction is applied by an AddressPolicy to an address component
+type AddressAction int
+
+// Actions of an AddressPolicy
+const (
+	// AddressDrop removes the component. Components missing from a policy are dropped
+	AddressDrop AddressAction = iota
+	// AddressKeep leaves the component in the clear
+	AddressKeep
+	// AddressMask masks every letter and digit of the component
+	AddressMask
+	// AddressGeneralize keeps the leading part of postal codes which identifies an area, and masks other components
+	AddressGeneralize
+)
+
+// AddressPolicy selects the action applied to each component of an address
+type AddressPolicy map[AddressComponent]AddressAction
+
+// DefaultAddressPolicy keeps the city, region and country, generalizes the postal code and drops the rest
+var DefaultAddressPolicy = AddressPolicy{
+	ACity:       AddressKeep,
+	ARegion:     AddressKeep,
+	ACountry:    AddressKeep,
+	APostalCode: AddressGeneralize,
+}
+
+// AddressPart is a component of a parsed address, with its byte offsets in the address
+type AddressPart struct {
+	Component AddressComponent
+	Value     string
+	Start     int
+	End       int
+}
+
+// ParsedAddress is a postal address split in components
+type ParsedAddress struct {
+	// Country is the ISO 3166-1 alpha-2 code of the country of the address, empty if unknown
+	Country string
+	// Parts are the components of the address, in order
+	Parts []AddressPart
+}
+
+var addressCountries = map[string]string{
+	"usa": "US", "us": "US", "united states": "US", "united states of america": "US",
+	"canada": "CA",
+	"uk":     "GB", "united kingdom": "GB", "great britain": "GB", "england": "GB", "scotland": "GB", "wales": "GB",
+	"australia": "AU",
+	"germany":   "DE", "deutschland": "DE",
+	"austria": "AT", "österreich": "AT",
+	"switzerland": "CH", "schweiz": "CH", "suisse": "CH",
+	"france":      "FR",
+	"netherlands": "NL", "nederland": "NL", "the netherlands": "NL",
+	"taiwan": "TW", "臺灣": "TW", "台灣": "TW",
+}
+
+// Patterns of the locality of an address: city, region and postal code. The first country of a pattern is assumed
+// when the address doesn't name one. When the city group is empty, the city is the previous segment.
+var addressLocalities = []struct {
+	countries []string
+	regex     *regexp.Regexp
+}{
+	{countries: []string{"US"}, regex: regexp.MustCompile(`^(?:(?P<city>.+?)\s+)?(?P<region>[A-Z]{2})\s+(?P<postal>\d{5}(?:-\d{4})?)$`)},
+	{countries: []string{"CA"}, regex: regexp.MustCompile(`^(?:(?P<city>.+?)\s+)?(?P<region>[A-Z]{2})\s+(?P<postal>[A-Z]\d[A-Z] ?\d[A-Z]\d)$`)},
+	{countries: []string{"AU"}, regex: regexp.MustCompile(`^(?:(?P<city>.+?)\s+)?(?P<region>NSW|VIC|QLD|WA|SA|TAS|ACT|NT)\s+(?P<postal>\d{4})$`)},
+	{countries: []string{"GB"}, regex: regexp.MustCompile(`^(?:(?P<city>.+?)\s+)?(?P<postal>[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2})$`)},
+	{countries: []string{"NL"}, regex: regexp.MustCompile(`^(?P<postal>\d{4} ?[A-Z]{2})\s+(?P<city>.+)$`)},
+	{countries: []string{"DE", "FR"}, regex: regexp.MustCompile(`^(?P<postal>\d{5})\s+(?P<city>.+)$`)},
+	{countries: []string{"CH", "AT"}, regex: regexp.MustCompile(`^(?P<postal>\d{4})\s+(?P<city>.+)$`)},
+}
+
+var (
+	addressUnitRegex        = regexp.MustCompile(`(?i)^(?:apt|apartment|unit|suite|ste|flat|floor|fl|room|rm|#)\.?\s*\S+$`)
+	addressInlineUnitRegex  = regexp.MustCompile(`(?i)^(.+?)\s+((?:apt|apartment|unit|suite|ste|#)\.?\s*\S+)$`)
+	addressLeadingNumber    = regexp.MustCompile(`^(\d+[A-Za-z]?(?:[-/]\d+[A-Za-z]?)?)\s+(.+)$`)
+	addressTrailingNumber   = regexp.MustCompile(`^(.+?)\s+(\d+[A-Za-z]?(?:[-/]\d+[A-Za-z]?)?)$`)
+	taiwaneseAddressPattern = regexp.MustCompile(`^(?P<postal>\d{3}(?:\d{2,3})?)?\s*(?P<region>\S{2}[市縣])(?P<city>\S+?[區鄉鎮市])(?P<street>\S+?(?:路|街|大道|道)(?:[一二三四五六七八九十]+段)?(?:\d+巷)?(?:\d+弄)?)(?P<house>\d+(?:之\d+)?號(?:之\d+)?)(?P<unit>.+)?$`)
+)
+
+// ParseAddress splits a postal address in components. It understands comma separated addresses of the United States,
+// Canada, the United Kingdom, Australia, Germany, Austria, Switzerland, France and the Netherlands, and Taiwanese
+// addresses. It returns false if the address isn't recognized.
+func ParseAddress(i string) (ParsedAddress, bool) {
+	if parsed, ok := parseTaiwaneseAddress(i); ok {
+		return parsed, true
+	}
+
+	type segment struct {
+		value      string
+		start, end int
+	}
+	var segments []segment
+	offset := 0
+	for _, s := range strings.Split(i, ",") {
+		trimmed := strings.TrimSpace(s)
+		if trimmed != "" {
+			start := offset + strings.Index(s, trimmed)
+			segments = append(segments, segment{value: trimmed, start: start, end: start + len(trimmed)})
+		}
+		offset += len(s) + 1
+	}
+	if len(segments) < 2 {
+		return ParsedAddress{}, false
+	}
+
+	var parsed ParsedAddress
+	add := func(component AddressComponent, start, end int) {
+		parsed.Parts = append(parsed.Parts, AddressPart{Component: component, Value: i[start:end], Start: start, End: end})
+	}
+
+	last := segments[len(segments)-1]
+	if country, ok := addressCountries[strings.ToLower(last.value)]; ok {
+		parsed.Country = country
+		add(ACountry, last.start, last.end)
+		segments = segments[:len(segments)-1]
+	}
+
+	// the locality is the last remaining segment, the ones before it are the street and unit
+	locality := len(segments) - 1
+	if locality < 1 {
+		return ParsedAddress{}, false
+	}
+	matched := false
+	for _, pattern := range addressLocalities {
+		if parsed.Country != "" && !containsString(pattern.countries, parsed.Country) {
+			continue
+		}
+		match := pattern.regex.FindStringSubmatchIndex(segments[locality].value)
+		if match == nil {
+			continue
+		}
+		if parsed.Country == "" {
+			parsed.Country = pattern.countries[0]
+		}
+		base := segments[locality].start
+		for g, name := range pattern.regex.SubexpNames() {
+			if match[2*g] < 0 {
+				continue
+			}
+			switch name {
+			case "city":
+				add(ACity, base+match[2*g], base+match[2*g+1])
+			case "region":
+				add(ARegion, base+match[2*g], base+match[2*g+1])
+			case "postal":
+				add(APostalCode, base+match[2*g], base+match[2*g+1])
+			}
+		}
+		if pattern.regex.SubexpNames()[1] == "city" && match[2] < 0 {
+			locality--
+			add(ACity, segments[locality].start, segments[locality].end)
+		}
+		matched = true
+		break
+	}
+	if !matched {
+		return ParsedAddress{}, false
+	}
+
+	for _, s := range segments[:locality] {
+		switch {
+		case addressUnitRegex.MatchString(s.value):
+			add(AUnit, s.start, s.end)
+			continue
+		case addressInlineUnitRegex.MatchString(s.value):
+			match := addressInlineUnitRegex.FindStringSubmatchIndex(s.value)
+			add(AUnit, s.start+match[4], s.start+match[5])
+			s.value, s.end = s.value[:match[3]], s.start+match[3]
+		}
+		if match := addressLeadingNumber.FindStringSubmatchIndex(s.value); match != nil {
+			add(AHouseNumber, s.start+match[2], s.start+match[3])
+			add(AStreet, s.start+match[4], s.start+match[5])
+		} else if match := addressTrailingNumber.FindStringSubmatchIndex(s.value); match != nil {
+			add(AStreet, s.start+match[2], s.start+match[3])
+			add(AHouseNumber, s.start+match[4], s.start+match[5])
+		} else {
+			add(AStreet, s.start, s.end)
+		}
+	}
+
+	sort.Slice(parsed.Parts, func(a, b int) bool {
+		return parsed.Parts[a].Start < parsed.Parts[b].Start
+	})
+	return parsed, true
+}
+
+func parseTaiwaneseAddress(i string) (ParsedAddress, bool) {
+	trimmed := strings.TrimSpace(i)
+	match := taiwaneseAddressPattern.FindStringSubmatchIndex(trimmed)
+	if match == nil {
+		return ParsedAddress{}, false
+	}
+	lead := strings.Index(i, trimmed)
+	parsed := ParsedAddress{Country: "TW"}
+	components := map[string]AddressComponent{
+		"postal": APostalCode,
+		"region": ARegion,
+		"city":   ACity,
+		"street": AStreet,
+		"house":  AHouseNumber,
+		"unit":   AUnit,
+	}
+	for g, name := range taiwaneseAddressPattern.SubexpNames() {
+		if g == 0 || match[2*g] < 0 {
+			continue
+		}
+		start, end := lead+match[2*g], lead+match[2*g+1]
+		parsed.Parts = append(parsed.Parts, AddressPart{Component: components[name], Value: i[start:end], Start: start, End: end})
+	}
+	return parsed, true
+}
+
+// UpdateAddressPolicy makes Address parse addresses in components and mask them with the policy. Addresses which
+// aren't recognized are masked entirely. Pass nil to keep the first 6 letters of addresses instead.
+func (m *Masker) UpdateAddressPolicy(policy AddressPolicy) {
+	m.address = policy
+}
+
+// maskAddress masks the components of an address with the address policy of the masker
+func (m *Masker) maskAddress(i string) string {
+	parsed, ok := ParseAddress(i)
+	if !ok {
+		return m.MaskWithSpec(alphanumericSpec, i)
+	}
+
+	var b strings.Builder
+	lastEmitted := -1
+	for idx, part := range parsed.Parts {
+		value := m.addressPart(parsed.Country, part)
+		if m.address[part.Component] == AddressDrop {
+			continue
+		}
+		if lastEmitted >= 0 {
+			// components separated by dropped ones are joined with the separator following the last emitted one
+			b.WriteString(i[parsed.Parts[lastEmitted].End:parsed.Parts[lastEmitted+1].Start])
+		}
+		b.WriteString(value)
+		lastEmitted = idx
+	}
+	return b.String()
+}
+
+func (m *Masker) addressPart(country string, part AddressPart) string {
+	switch m.address[part.Component] {
+	case AddressKeep:
+		return part.Value
+	case AddressGeneralize:
+		if part.Component == APostalCode {
+			return m.MaskWithSpec(MaskSpec{KeepFirst: postalCodeArea(country, part.Value), Classes: CLetters | CDigits}, part.Value)
+		}
+	}
+	return m.MaskWithSpec(alphanumericSpec, part.Value)
+}
+
+// postalCodeArea returns the number of leading letters and digits of a postal code identifying an area
+func postalCodeArea(country string, postal string) int {
+	switch country {
+	case "US", "CA", "TW":
+		return 3
+	case "GB":
+		return len(strings.ReplaceAll(postal, " ", "")) - 3
+	}
+	return 2
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
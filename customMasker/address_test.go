@@ -0,0 +1,169 @@
+package customMasker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAddress(t *testing.T) {
+	type component struct {
+		Component AddressComponent
+		Value     string
+	}
+	tests := []struct {
+		name        string
+		i           string
+		wantCountry string
+		want        []component
+		wantOk      bool
+	}{
+		{
+			name:        "United States",
+			i:           "123 Main St Apt 4, Springfield, IL 62704, USA",
+			wantCountry: "US",
+			want: []component{
+				{AHouseNumber, "123"}, {AStreet, "Main St"}, {AUnit, "Apt 4"}, {ACity, "Springfield"},
+				{ARegion, "IL"}, {APostalCode, "62704"}, {ACountry, "USA"},
+			},
+			wantOk: true,
+		},
+		{
+			name:        "Canada Without Country",
+			i:           "24 Sussex Drive, Ottawa ON K1M 1M4",
+			wantCountry: "CA",
+			want: []component{
+				{AHouseNumber, "24"}, {AStreet, "Sussex Drive"}, {ACity, "Ottawa"}, {ARegion, "ON"}, {APostalCode, "K1M 1M4"},
+			},
+			wantOk: true,
+		},
+		{
+			name:        "United Kingdom",
+			i:           "Flat 2, 10 Downing Street, London SW1A 2AA, United Kingdom",
+			wantCountry: "GB",
+			want: []component{
+				{AUnit, "Flat 2"}, {AHouseNumber, "10"}, {AStreet, "Downing Street"}, {ACity, "London"},
+				{APostalCode, "SW1A 2AA"}, {ACountry, "United Kingdom"},
+			},
+			wantOk: true,
+		},
+		{
+			name:        "Australia",
+			i:           "1 Macquarie St, Sydney NSW 2000",
+			wantCountry: "AU",
+			want: []component{
+				{AHouseNumber, "1"}, {AStreet, "Macquarie St"}, {ACity, "Sydney"}, {ARegion, "NSW"}, {APostalCode, "2000"},
+			},
+			wantOk: true,
+		},
+		{
+			name:        "Germany",
+			i:           "Hauptstraße 5, 10115 Berlin, Germany",
+			wantCountry: "DE",
+			want: []component{
+				{AStreet, "Hauptstraße"}, {AHouseNumber, "5"}, {APostalCode, "10115"}, {ACity, "Berlin"}, {ACountry, "Germany"},
+			},
+			wantOk: true,
+		},
+		{
+			name:        "France",
+			i:           "55 Rue du Faubourg Saint-Honoré, 75008 Paris, France",
+			wantCountry: "FR",
+			want: []component{
+				{AHouseNumber, "55"}, {AStreet, "Rue du Faubourg Saint-Honoré"}, {APostalCode, "75008"}, {ACity, "Paris"},
+				{ACountry, "France"},
+			},
+			wantOk: true,
+		},
+		{
+			name:        "Taiwan",
+			i:           "114台北市內湖區內湖路一段737巷1號1樓",
+			wantCountry: "TW",
+			want: []component{
+				{APostalCode, "114"}, {ARegion, "台北市"}, {ACity, "內湖區"}, {AStreet, "內湖路一段737巷"}, {AHouseNumber, "1號"},
+				{AUnit, "1樓"},
+			},
+			wantOk: true,
+		},
+		{
+			name:   "Not An Address",
+			i:      "1 AB Road Paradise",
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseAddress(tt.i)
+			if ok != tt.wantOk {
+				t.Fatalf("ParseAddress() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !ok {
+				return
+			}
+			var components []component
+			for _, part := range got.Parts {
+				if tt.i[part.Start:part.End] != part.Value {
+					t.Errorf("ParseAddress() part %v has offsets of %q", part, tt.i[part.Start:part.End])
+				}
+				components = append(components, component{part.Component, part.Value})
+			}
+			if got.Country != tt.wantCountry || !reflect.DeepEqual(components, tt.want) {
+				t.Errorf("ParseAddress() = %v %v, want %v %v", got.Country, components, tt.wantCountry, tt.want)
+			}
+		})
+	}
+}
+
+func TestMasker_AddressPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy AddressPolicy
+		i      string
+		want   string
+	}{
+		{
+			name:   "Default Policy",
+			policy: DefaultAddressPolicy,
+			i:      "123 Main St Apt 4, Springfield, IL 62704-1234, USA",
+			want:   "Springfield, IL 627**-****, USA",
+		},
+		{
+			name:   "Default Policy United Kingdom",
+			policy: DefaultAddressPolicy,
+			i:      "10 Downing Street, London SW1A 2AA",
+			want:   "London SW1A ***",
+		},
+		{
+			name:   "Default Policy Germany",
+			policy: DefaultAddressPolicy,
+			i:      "Hauptstraße 5, 10115 Berlin, Germany",
+			want:   "10*** Berlin, Germany",
+		},
+		{
+			name:   "Default Policy Taiwan",
+			policy: DefaultAddressPolicy,
+			i:      "台北市內湖區內湖路一段737巷1號1樓",
+			want:   "台北市內湖區",
+		},
+		{
+			name:   "Mask Street",
+			policy: AddressPolicy{AHouseNumber: AddressMask, AStreet: AddressKeep, ACity: AddressKeep},
+			i:      "1 AB Road, Paradise, NV 89109",
+			want:   "* AB Road, Paradise",
+		},
+		{
+			name:   "Not An Address",
+			policy: DefaultAddressPolicy,
+			i:      "1 AB Road Paradise",
+			want:   "* ** **** ********",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMasker()
+			m.UpdateAddressPolicy(tt.policy)
+			if got := m.Address(tt.i); got != tt.want {
+				t.Errorf("Masker.Address() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,16 +4,34 @@ type Mtype string
 
 // Mask Types of format string
 const (
-	MPassword   Mtype = "password"
-	MName       Mtype = "name"
-	MAddress    Mtype = "addr"
-	MEmail      Mtype = "email"
-	MMobile     Mtype = "mobile"
-	MTelephone  Mtype = "tel"
-	MID         Mtype = "id"
-	MCreditCard Mtype = "credit"
-	MURL        Mtype = "url"
-	MSecret     Mtype = "secret"
+	MPassword          Mtype = "password"
+	MName              Mtype = "name"
+	MAddress           Mtype = "addr"
+	MEmail             Mtype = "email"
+	MMobile            Mtype = "mobile"
+	MTelephone         Mtype = "tel"
+	MPhone             Mtype = "phone"
+	MID                Mtype = "id"
+	MCreditCard        Mtype = "credit"
+	MURL               Mtype = "url"
+	MDSN               Mtype = "dsn"
+	MSecret            Mtype = "secret"
+	MPseudonym         Mtype = "pseudonym"
+	MEncrypt           Mtype = "encrypt"
+	MTokenDigits       Mtype = "token_digits"
+	MTokenAlphanumeric Mtype = "token_alnum"
+	MFakeName          Mtype = "fake_name"
+	MFakeEmail         Mtype = "fake_email"
+	MFakePhone         Mtype = "fake_phone"
+	MFakeAddress       Mtype = "fake_addr"
+	MFakeCreditCard    Mtype = "fake_credit"
+	MYear              Mtype = "year"
+	MAgeBand           Mtype = "age_band"
+	MPostalPrefix      Mtype = "postal_prefix"
+	MGeoGrid           Mtype = "geo_grid"
+	MReference         Mtype = "ref"
+	MHashSHA256        Mtype = "sha256"
+	MHashBLAKE2b       Mtype = "blake2b"
 )
 
 type MaskingCharacter string
@@ -24,4 +42,16 @@ const (
 	PHyphen     MaskingCharacter = "-"
 	PUnderscore MaskingCharacter = "_"
 	PCross      MaskingCharacter = "x"
+	PBullet     MaskingCharacter = "•"
+)
+
+// MaskStyle is how a masker replaces masked characters
+type MaskStyle uint8
+
+// MaskStyle Types
+const (
+	// SMaskingCharacter replaces masked characters with the masking character
+	SMaskingCharacter MaskStyle = iota
+	// SClassPreserving masks digits with "#" and other characters with "X"
+	SClassPreserving
 )
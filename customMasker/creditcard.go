@@ -0,0 +1,76 @@
+package customMasker
+
+// CardRule selects the digits of a card number left in the clear
+type CardRule int
+
+// Card rules of a CardPolicy
+const (
+	// CardFirst6Last4 keeps the issuer identification number and the last 4 digits
+	CardFirst6Last4 CardRule = iota
+	// CardLast4 keeps only the last 4 digits
+	CardLast4
+)
+
+// Lengths of a primary account number
+const (
+	minCardDigits = 13
+	maxCardDigits = 19
+)
+
+// CardPolicy configures credit card masking
+type CardPolicy struct {
+	// Rule selects the digits left in the clear
+	Rule CardRule
+	// ValidateLuhn only treats strings with a valid Luhn check digit as card numbers
+	ValidateLuhn bool
+}
+
+// UpdateCardPolicy updates the policy used to mask credit card numbers
+func (m *Masker) UpdateCardPolicy(policy CardPolicy) {
+	m.card = policy
+}
+
+// IsCreditCard reports whether the string holds 13 to 19 digits, ignoring separators, and optionally whether they
+// have a valid Luhn check digit
+func (p CardPolicy) IsCreditCard(i string) bool {
+	digits := 0
+	for _, c := range i {
+		if c >= '0' && c <= '9' {
+			digits++
+		}
+	}
+	if digits < minCardDigits || digits > maxCardDigits {
+		return false
+	}
+	return !p.ValidateLuhn || LuhnValid(i)
+}
+
+// LuhnValid reports whether the digits of the string, ignoring any other character, have a valid Luhn check digit
+func LuhnValid(i string) bool {
+	sum := 0
+	digits := 0
+	for idx := len(i) - 1; idx >= 0; idx-- {
+		c := i[idx]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if digits%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+	}
+	return digits > 0 && sum%10 == 0
+}
+
+func (p CardPolicy) spec() MaskSpec {
+	spec := MaskSpec{KeepLast: 4, Classes: CDigits}
+	if p.Rule == CardFirst6Last4 {
+		spec.KeepFirst = 6
+	}
+	return spec
+}
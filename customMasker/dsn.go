@@ -0,0 +1,231 @@
+package customMasker
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	dsnURLRegex    = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*://`)
+	dsnMySQLRegex  = regexp.MustCompile(`^([^:@/]*)(:.*)?@([A-Za-z0-9]*(?:\([^)]*\))?/[^?]*)(?:\?(.*))?$`)
+	dsnOracleRegex = regexp.MustCompile(`(?i)^(jdbc:oracle:[a-z0-9]+:[^/@:]*)/([^@]*)(@.*)$`)
+)
+
+// dsnSecretKeys are normalized keys of connection string parameters holding secrets, in addition to the ones ending
+// with "password", "secret" or "token"
+var dsnSecretKeys = []string{"pwd", "passwd", "pass", "accountkey", "sharedaccesskey", "apikey"}
+
+// dsnSecretKey reports whether a connection string parameter holds a secret. Keys are compared case-insensitively,
+// ignoring spaces, hyphens and underscores.
+func dsnSecretKey(key string) bool {
+	normalized := strings.Map(func(c rune) rune {
+		if c == ' ' || c == '-' || c == '_' {
+			return -1
+		}
+		return c
+	}, strings.ToLower(strings.TrimSpace(key)))
+	if strings.HasSuffix(normalized, "password") || strings.HasSuffix(normalized, "secret") ||
+		strings.HasSuffix(normalized, "token") {
+		return true
+	}
+	return containsString(dsnSecretKeys, normalized)
+}
+
+// DSN mask the passwords and secrets of a connection string, keeping the user, hosts and database names. It
+// recognizes URLs (postgres://, mongodb+srv://, redis://...), MySQL DSNs, JDBC URLs, ADO.NET connection strings and
+// libpq key/value connection strings. Unrecognized connection strings are masked entirely.
+//
+// Example:
+//
+//	input: admin:secret@tcp(db:3306)/shop?parseTime=true
+//	output: admin:*****@tcp(db:3306)/shop?parseTime=true
+//	input: Server=db;Database=shop;User Id=admin;Password=secret;
+//	output: Server=db;Database=shop;User Id=admin;Password=*****;
+func (m *Masker) DSN(i string) string {
+	if i == "" {
+		return ""
+	}
+	switch {
+	case strings.HasPrefix(strings.ToLower(i), "jdbc:"):
+		return m.jdbc(i)
+	case dsnURLRegex.MatchString(i):
+		return m.dsnURL(i)
+	case dsnMySQLRegex.MatchString(i):
+		match := dsnMySQLRegex.FindStringSubmatchIndex(i)
+		masked := i[match[2]:match[3]]
+		if match[4] >= 0 {
+			masked += ":" + m.dsnMask()
+		}
+		masked += "@" + i[match[6]:match[7]]
+		// the password may contain "?", so the query is only the one matched after the database name
+		if match[8] >= 0 {
+			masked += "?" + m.maskParams(i[match[8]:match[9]], dsnSecretKey)
+		}
+		return masked
+	case strings.Contains(i, ";") && strings.Contains(i, "="):
+		return m.maskKeyValues(i, ';')
+	case strings.Contains(i, "="):
+		return m.maskKeyValues(i, ' ')
+	}
+	return m.MaskWithSpec(alphanumericSpec, i)
+}
+
+// dsnMask returns the masking characters replacing a secret, which hides its length
+func (m *Masker) dsnMask() string {
+	return m.urlMask()
+}
+
+// jdbc masks JDBC URLs, including the user/password of Oracle and the properties of SQL Server. Sub-protocols like
+// jdbc:mysql:loadbalance:// are kept in the prefix, and the properties of non-URL forms like jdbc:db2:shop:user=a;
+// are masked as key/value pairs.
+func (m *Masker) jdbc(i string) string {
+	if match := dsnOracleRegex.FindStringSubmatch(i); match != nil {
+		return match[1] + "/" + m.dsnMask() + match[3]
+	}
+	prefix, rest := i[:len("jdbc:")], i[len("jdbc:"):]
+	if scheme := strings.Index(rest, "://"); scheme >= 0 && !strings.ContainsAny(rest[:scheme], "/@?;&=") {
+		if colon := strings.LastIndex(rest[:scheme], ":"); colon >= 0 {
+			prefix, rest = prefix+rest[:colon+1], rest[colon+1:]
+		}
+	}
+	if !dsnURLRegex.MatchString(rest) {
+		if strings.Contains(rest, "=") {
+			return prefix + m.maskJDBCProperties(rest)
+		}
+		return prefix + m.MaskWithSpec(alphanumericSpec, rest)
+	}
+	if semicolon := strings.Index(rest, ";"); semicolon >= 0 {
+		return prefix + m.dsnURL(rest[:semicolon]) + m.maskKeyValues(rest[semicolon:], ';')
+	}
+	return prefix + m.dsnURL(rest)
+}
+
+// maskJDBCProperties masks the secret values of key=value properties separated by semicolons or ampersands
+func (m *Masker) maskJDBCProperties(i string) string {
+	var b strings.Builder
+	for i != "" {
+		end := strings.IndexAny(i, ";&")
+		if end < 0 {
+			end = len(i)
+		}
+		property := i[:end]
+		if equal := strings.Index(property, "="); equal >= 0 {
+			key := property[:equal]
+			if colon := strings.LastIndex(key, ":"); colon >= 0 {
+				key = key[colon+1:]
+			}
+			if dsnSecretKey(key) && strings.TrimSpace(property[equal+1:]) != "" {
+				property = property[:equal+1] + m.dsnMask()
+			}
+		}
+		b.WriteString(property)
+		if end < len(i) {
+			b.WriteByte(i[end])
+			end++
+		}
+		i = i[end:]
+	}
+	return b.String()
+}
+
+// dsnURL masks the password of the user information and the secret query parameters of a connection URL. Unlike
+// url.Parse, it accepts lists of hosts like mongodb://a:27017,b:27017/db
+func (m *Masker) dsnURL(i string) string {
+	scheme := dsnURLRegex.FindString(i)
+	rest, fragment := i[len(scheme):], ""
+	if hash := strings.Index(rest, "#"); hash >= 0 {
+		rest, fragment = rest[:hash], rest[hash:]
+	}
+	query := ""
+	hasQuery := false
+	if question := strings.Index(rest, "?"); question >= 0 {
+		rest, query, hasQuery = rest[:question], rest[question+1:], true
+	}
+
+	// passwords may contain "@" and "/", so the user information ends at the last "@"
+	if at := strings.LastIndex(rest, "@"); at >= 0 {
+		userinfo := rest[:at]
+		if colon := strings.Index(userinfo, ":"); colon >= 0 && colon < len(userinfo)-1 {
+			userinfo = userinfo[:colon+1] + m.dsnMask()
+		}
+		rest = userinfo + rest[at:]
+	}
+
+	masked := scheme + rest
+	if hasQuery {
+		masked += "?" + m.maskParams(query, dsnSecretKey)
+	}
+	return masked + fragment
+}
+
+// maskKeyValues masks the secret values of key=value pairs separated by semicolons (ADO.NET) or spaces (libpq).
+// Quoted values may contain separators: ADO.NET escapes quotes by doubling them, and libpq with backslashes.
+func (m *Masker) maskKeyValues(i string, separator byte) string {
+	isSeparator := func(c byte) bool {
+		if separator == ' ' {
+			return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+		}
+		return c == separator
+	}
+
+	var b strings.Builder
+	pos := 0
+	for pos < len(i) {
+		if isSeparator(i[pos]) {
+			b.WriteByte(i[pos])
+			pos++
+			continue
+		}
+
+		// the key ends at "=", optionally preceded by spaces in libpq
+		start := pos
+		for pos < len(i) && i[pos] != '=' && !isSeparator(i[pos]) {
+			pos++
+		}
+		key := i[start:pos]
+		for separator == ' ' && pos < len(i) && i[pos] == ' ' {
+			pos++
+		}
+		if pos >= len(i) || i[pos] != '=' {
+			b.WriteString(i[start:pos])
+			continue
+		}
+		pos++
+		for pos < len(i) && (i[pos] == ' ' || i[pos] == '\t') {
+			pos++
+		}
+		b.WriteString(i[start:pos])
+
+		valueStart := pos
+		pos = keyValueEnd(i, pos, separator, isSeparator)
+		value := i[valueStart:pos]
+		if dsnSecretKey(key) && strings.TrimSpace(value) != "" {
+			b.WriteString(m.dsnMask())
+			continue
+		}
+		b.WriteString(value)
+	}
+	return b.String()
+}
+
+// keyValueEnd returns the end of the value starting at pos
+func keyValueEnd(i string, pos int, separator byte, isSeparator func(c byte) bool) int {
+	if pos < len(i) && (i[pos] == '\'' || (separator == ';' && i[pos] == '"')) {
+		quote := i[pos]
+		for pos++; pos < len(i); pos++ {
+			switch {
+			case separator == ' ' && i[pos] == '\\':
+				pos++
+			case i[pos] == quote && separator == ';' && pos+1 < len(i) && i[pos+1] == quote:
+				pos++
+			case i[pos] == quote:
+				return pos + 1
+			}
+		}
+		return len(i)
+	}
+	for pos < len(i) && !isSeparator(i[pos]) {
+		pos++
+	}
+	return pos
+}
@@ -0,0 +1,145 @@
+package customMasker
+
+import (
+	"net/mail"
+	"strings"
+)
+
+// EmailLocalStrategy selects how the local part of an email address is masked
+type EmailLocalStrategy int
+
+// Local part strategies of an EmailPolicy
+const (
+	// EmailKeepFirst3 keeps the first 3 characters of the local part and replaces the rest with 4 masking characters
+	EmailKeepFirst3 EmailLocalStrategy = iota
+	// EmailMaskLocal masks every character of the local part
+	EmailMaskLocal
+	// EmailHashLocal replaces the local part with the first 10 hex digits of its HMAC-SHA256 with a subkey of the
+	// key of the pseudonym policy, so the same address is always masked the same way with the same key. It needs a
+	// key: without one, the local part is masked entirely. Hashes carry no key ID, so they change when the active key
+	// of a key provider rotates.
+	EmailHashLocal
+)
+
+// EmailDomainStrategy selects how the domain of an email address is masked
+type EmailDomainStrategy int
+
+// Domain strategies of an EmailPolicy
+const (
+	// EmailKeepDomain leaves the domain in the clear
+	EmailKeepDomain EmailDomainStrategy = iota
+	// EmailMaskDomainExceptTLD masks every label of the domain except the top-level domain
+	EmailMaskDomainExceptTLD
+	// EmailMaskDomain masks every label of the domain
+	EmailMaskDomain
+)
+
+// EmailPolicy configures email masking. The zero value keeps the first 3 characters of the local part and the domain.
+type EmailPolicy struct {
+	// Local selects how the local part is masked
+	Local EmailLocalStrategy
+	// Domain selects how the domain is masked
+	Domain EmailDomainStrategy
+	// KeepTag leaves the plus-addressing tag of the local part in the clear, e.g. "+newsletter"
+	KeepTag bool
+}
+
+const emailHashLength = 10
+
+// UpdateEmailPolicy updates the policy used to mask email addresses
+func (m *Masker) UpdateEmailPolicy(policy EmailPolicy) {
+	m.email = policy
+}
+
+// maskEmailList masks a list of RFC 5322 addresses separated by commas. Display names are masked like names. It returns
+// false if the input isn't a valid address list.
+func (m *Masker) maskEmailList(i string) (string, bool) {
+	addresses, err := mail.ParseAddressList(i)
+	if err != nil || len(addresses) == 0 {
+		return "", false
+	}
+	masked := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		at := strings.LastIndex(address.Address, "@")
+		addr := quoteLocalPart(m.emailLocalPart(address.Address[:at])) + "@" + m.emailDomain(address.Address[at+1:])
+		if address.Name != "" {
+			addr = quoteString(m.Name(address.Name)) + " <" + addr + ">"
+		}
+		masked = append(masked, addr)
+	}
+	return strings.Join(masked, ", "), true
+}
+
+func (m *Masker) emailLocalPart(local string) string {
+	tag := ""
+	if m.email.KeepTag {
+		if idx := strings.Index(local, "+"); idx > 0 {
+			local, tag = local[:idx], local[idx:]
+		}
+	}
+	switch m.email.Local {
+	case EmailMaskLocal:
+		local = m.MaskWithSpec(MaskSpec{}, local)
+	case EmailHashLocal:
+		if _, key, err := m.pseudonym.activeKey(); err == nil {
+			local = m.pseudonym.digest(deriveKey(key, keyPurposeEmail), local)[:emailHashLength]
+		} else {
+			local = m.MaskWithSpec(MaskSpec{}, local)
+		}
+	default:
+		local = m.MaskWithSpec(emailSpec, local)
+	}
+	return local + tag
+}
+
+func (m *Masker) emailDomain(domain string) string {
+	switch m.email.Domain {
+	case EmailMaskDomainExceptTLD:
+		labels := strings.Split(domain, ".")
+		for idx := range labels[:len(labels)-1] {
+			labels[idx] = m.MaskWithSpec(MaskSpec{}, labels[idx])
+		}
+		return strings.Join(labels, ".")
+	case EmailMaskDomain:
+		return m.MaskWithSpec(MaskSpec{Separators: "."}, domain)
+	}
+	return domain
+}
+
+// quoteLocalPart quotes a local part which isn't a dot-atom, e.g. one containing spaces
+func quoteLocalPart(local string) string {
+	atom := local != "" && !strings.HasPrefix(local, ".") && !strings.HasSuffix(local, ".") &&
+		!strings.Contains(local, "..")
+	for _, c := range local {
+		if !isAtext(c) && c != '.' {
+			atom = false
+			break
+		}
+	}
+	if atom {
+		return local
+	}
+	return quoteString(local)
+}
+
+func quoteString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, c := range s {
+		if c == '"' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// isAtext reports whether the character may appear unquoted in an atom of RFC 5322, allowing UTF-8 like RFC 6532
+func isAtext(c rune) bool {
+	if c >= 0x80 {
+		return true
+	}
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') ||
+		strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", c)
+}
@@ -0,0 +1,86 @@
+package customMasker
+
+import "testing"
+
+func TestMasker_EmailPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy EmailPolicy
+		key    []byte
+		i      string
+		want   string
+	}{
+		{
+			name: "Display Name",
+			i:    `"Jane Doe" <jane@x.com>`,
+			want: `"J**e D**e" <jan****@x.com>`,
+		},
+		{
+			name: "Address List",
+			i:    "dummy@dummy.com, Bob <bob@y.com>",
+			want: `dum****@dummy.com, "B**b" <bob****@y.com>`,
+		},
+		{
+			name: "Quoted Local Part",
+			i:    `"john@doe"@example.com`,
+			want: `joh****e@example.com`,
+		},
+		{
+			name: "Quoted Local Part Not RFC 5322",
+			i:    `john@doe@example.com`,
+			want: `joh****e@example.com`,
+		},
+		{
+			name: "Quoted Short Local Part",
+			i:    `"j d"@example.com`,
+			want: `"j d****"@example.com`,
+		},
+		{
+			name:   "Mask Domain Except TLD",
+			policy: EmailPolicy{Domain: EmailMaskDomainExceptTLD},
+			i:      "dummy@mail.dummy.com",
+			want:   "dum****@****.*****.com",
+		},
+		{
+			name:   "Mask Domain",
+			policy: EmailPolicy{Local: EmailMaskLocal, Domain: EmailMaskDomain},
+			i:      "dummy@dummy.com",
+			want:   "*****@*****.***",
+		},
+		{
+			name:   "Keep Tag",
+			policy: EmailPolicy{KeepTag: true},
+			i:      "dummy+news@dummy.com",
+			want:   "dum****+news@dummy.com",
+		},
+		{
+			name:   "Hash Local Part",
+			policy: EmailPolicy{Local: EmailHashLocal, KeepTag: true},
+			key:    []byte("0123456789abcdef0123456789abcdef"),
+			i:      "dummy+news@dummy.com",
+			want:   "0887e3cdba+news@dummy.com",
+		},
+		{
+			name:   "Hash Local Part Without Key",
+			policy: EmailPolicy{Local: EmailHashLocal, KeepTag: true},
+			i:      "dummy+news@dummy.com",
+			want:   "*****+news@dummy.com",
+		},
+		{
+			name:   "Not An Address",
+			policy: EmailPolicy{Domain: EmailMaskDomain},
+			i:      "abcd",
+			want:   "abc****",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMasker()
+			m.UpdateEmailPolicy(tt.policy)
+			m.UpdatePseudonymPolicy(PseudonymPolicy{Key: tt.key})
+			if got := m.Email(tt.i); got != tt.want {
+				t.Errorf("Masker.Email() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package customMasker
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrInvalidEncryptionKey is returned when adding an encryption key which isn't an AES key, or a key which is empty
+	// or whose ID is invalid
+	ErrInvalidEncryptionKey = errors.New("invalid encryption key")
+
+	// ErrUnknownKeyID is returned when decrypting a value encrypted with a key the masker doesn't know
+	ErrUnknownKeyID = errors.New("unknown encryption key id")
+
+	// ErrTamperedValue is returned when an encrypted value fails authentication: it was modified, or encrypted with
+	// another key of the same ID
+	ErrTamperedValue = errors.New("encrypted value failed authentication")
+
+	// ErrInvalidEncryptedValue is returned when decrypting a string which isn't an encrypted value
+	ErrInvalidEncryptedValue = errors.New("invalid encrypted value")
+)
+
+// encryptedPrefix starts encrypted values, followed by the key ID, ":" and the base64url nonce and ciphertext
+const encryptedPrefix = "enc:v1:"
+
+var (
+	encryptionKeyIDRegex  = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+	encryptedValueRegex   = regexp.MustCompile(`enc:v1:[A-Za-z0-9._-]+:[A-Za-z0-9_-]+`)
+	encryptedValuePattern = regexp.MustCompile(`^enc:v1:([A-Za-z0-9._-]+):([A-Za-z0-9_-]+)$`)
+)
+
+// UpdateEncryptionKey makes the masker encrypt values with an AES-128, AES-192 or AES-256 key. Key IDs may contain
+// letters, digits, ".", "_" and "-". Keys added before are still used to decrypt values. The key is added to the
+// Keyring of the masker, which replaces a key provider of another type set by UseKeyProvider.
+func (m *Masker) UpdateEncryptionKey(id string, key []byte) error {
+	if _, err := aes.NewCipher(key); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEncryptionKey, err)
+	}
+	keys, ok := m.keys.(*Keyring)
+	if !ok {
+		keys = NewKeyring()
+	}
+	if err := keys.AddKey(id, key, keys.now()); err != nil {
+		return err
+	}
+	m.keys = keys
+	return nil
+}
+
+// UseKeyProvider makes the masker take the keys of encryption from the provider, and the keys of pseudonyms and
+// tokens when their policies have no key. Each mask type derives its own subkey from the keys of the provider.
+// Encrypted values and pseudonyms carry the ID of their key, so values masked before a rotation of the provider can
+// still be decrypted and verified, as long as the provider keeps the old keys. Secret fingerprints and email hashes
+// use the pseudonym key but carry no key ID: they change after a rotation.
+func (m *Masker) UseKeyProvider(provider KeyProvider) error {
+	m.keys = provider
+	if len(m.pseudonym.Key) == 0 {
+		m.pseudonym.Keys = provider
+	}
+	if len(m.tokens.policy.Key) == 0 {
+		policy := m.tokens.policy
+		policy.Keys = provider
+		if err := m.UpdateTokenPolicy(policy); err != nil && !errors.Is(err, ErrNoActiveKey) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Masker) encryptionAEAD(id string) (cipher.AEAD, error) {
+	if m.keys == nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, id)
+	}
+	key, err := m.keys.Key(id)
+	if err != nil {
+		return nil, err
+	}
+	return newAEAD(key)
+}
+
+// newAEAD returns the AES-GCM cipher of the encryption subkey of key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(deriveKey(key, keyPurposeEncrypt))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEncryptionKey, err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypt replaces a value with its AES-GCM encryption with the encryption key of the masker, using a random nonce
+// per value. The key ID is embedded in the encrypted value and authenticated with it. Values are masked entirely if
+// the masker has no encryption key.
+//
+// Example:
+//
+//	input: dummy@dummy.com
+//	output: enc:v1:2024-01:mAq1x9b...
+func (m *Masker) Encrypt(i string) string {
+	if i == "" {
+		return ""
+	}
+	if m.keys == nil {
+		return m.MaskWithSpec(MaskSpec{}, i)
+	}
+	id, key, err := m.keys.ActiveKey()
+	if err != nil || !encryptionKeyIDRegex.MatchString(id) {
+		return m.MaskWithSpec(MaskSpec{}, i)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return m.MaskWithSpec(MaskSpec{}, i)
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(i)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return m.MaskWithSpec(MaskSpec{}, i)
+	}
+	header := encryptedPrefix + id + ":"
+	sealed := aead.Seal(nonce, nonce, []byte(i), []byte(header))
+	return header + base64.RawURLEncoding.EncodeToString(sealed)
+}
+
+// Decrypt restores the original of a value encrypted by Encrypt. It returns ErrUnknownKeyID if the masker doesn't
+// know the key of the value, and ErrTamperedValue if the value was modified.
+func (m *Masker) Decrypt(encrypted string) (string, error) {
+	match := encryptedValuePattern.FindStringSubmatch(encrypted)
+	if match == nil {
+		return "", ErrInvalidEncryptedValue
+	}
+	aead, err := m.encryptionAEAD(match[1])
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(match[2])
+	if err != nil || len(sealed) < aead.NonceSize()+aead.Overhead() {
+		return "", ErrInvalidEncryptedValue
+	}
+	header := encrypted[:len(encrypted)-len(match[2])]
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(header))
+	if err != nil {
+		return "", fmt.Errorf("%w: key id %q", ErrTamperedValue, match[1])
+	}
+	return string(plain), nil
+}
+
+// Unmask decrypts every encrypted value found in the string, e.g. values encrypted by regex filters in free text
+func (m *Masker) Unmask(i string) (string, error) {
+	if !strings.Contains(i, encryptedPrefix) {
+		return i, nil
+	}
+	var err error
+	unmasked := encryptedValueRegex.ReplaceAllStringFunc(i, func(encrypted string) string {
+		if err != nil {
+			return encrypted
+		}
+		var plain string
+		plain, err = m.Decrypt(encrypted)
+		return plain
+	})
+	if err != nil {
+		return "", err
+	}
+	return unmasked, nil
+}
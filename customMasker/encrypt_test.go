@@ -0,0 +1,81 @@
+package customMasker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var testEncryptionKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestMasker_Encrypt(t *testing.T) {
+	m := NewMasker()
+	if err := m.UpdateEncryptionKey("2024-01", testEncryptionKey); err != nil {
+		t.Fatalf("Masker.UpdateEncryptionKey() error = %v", err)
+	}
+
+	encrypted := m.Encrypt("dummy@dummy.com")
+	if !strings.HasPrefix(encrypted, "enc:v1:2024-01:") {
+		t.Fatalf("Masker.Encrypt() = %v, want key id 2024-01", encrypted)
+	}
+	if again := m.Encrypt("dummy@dummy.com"); again == encrypted {
+		t.Errorf("Masker.Encrypt() = %v twice, want a nonce per value", again)
+	}
+	if got, err := m.Decrypt(encrypted); err != nil || got != "dummy@dummy.com" {
+		t.Errorf("Masker.Decrypt() = %v, %v, want dummy@dummy.com", got, err)
+	}
+	if got, err := m.Unmask("mail " + encrypted + " and " + m.Encrypt("other")); err != nil || got != "mail dummy@dummy.com and other" {
+		t.Errorf("Masker.Unmask() = %v, %v", got, err)
+	}
+
+	tampered := encrypted[:len(encrypted)-2] + "AA"
+	if tampered == encrypted {
+		tampered = encrypted[:len(encrypted)-2] + "BB"
+	}
+	if _, err := m.Decrypt(tampered); !errors.Is(err, ErrTamperedValue) {
+		t.Errorf("Masker.Decrypt() error = %v, want ErrTamperedValue", err)
+	}
+	relabeled := strings.Replace(encrypted, "2024-01", "2024-02", 1)
+	if err := m.UpdateEncryptionKey("2024-02", testEncryptionKey); err != nil {
+		t.Fatalf("Masker.UpdateEncryptionKey() error = %v", err)
+	}
+	if _, err := m.Decrypt(relabeled); !errors.Is(err, ErrTamperedValue) {
+		t.Errorf("Masker.Decrypt() error = %v, want ErrTamperedValue for a changed key id", err)
+	}
+	if got, err := m.Decrypt(encrypted); err != nil || got != "dummy@dummy.com" {
+		t.Errorf("Masker.Decrypt() = %v, %v, want values of previous keys to decrypt", got, err)
+	}
+
+	other := NewMasker()
+	if _, err := other.Decrypt(encrypted); !errors.Is(err, ErrUnknownKeyID) {
+		t.Errorf("Masker.Decrypt() error = %v, want ErrUnknownKeyID", err)
+	}
+	if _, err := other.Decrypt("dummy@dummy.com"); !errors.Is(err, ErrInvalidEncryptedValue) {
+		t.Errorf("Masker.Decrypt() error = %v, want ErrInvalidEncryptedValue", err)
+	}
+}
+
+func TestMasker_UpdateEncryptionKey(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		key  []byte
+	}{
+		{name: "Short Key", id: "k1", key: []byte("short")},
+		{name: "Empty Key ID", id: "", key: testEncryptionKey},
+		{name: "Key ID With Colon", id: "k:1", key: testEncryptionKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := NewMasker().UpdateEncryptionKey(tt.id, tt.key); !errors.Is(err, ErrInvalidEncryptionKey) {
+				t.Errorf("Masker.UpdateEncryptionKey() error = %v, want ErrInvalidEncryptionKey", err)
+			}
+		})
+	}
+}
+
+func TestMasker_EncryptWithoutKey(t *testing.T) {
+	if got, want := NewMasker().Encrypt("dummy"), "*****"; got != want {
+		t.Errorf("Masker.Encrypt() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,236 @@
+package customMasker
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownLocale is returned when using a locale without fake dictionaries
+var ErrUnknownLocale = errors.New("unknown fake data locale")
+
+// defaultFakeLocale is the locale of fake values when the fake policy has none
+const defaultFakeLocale = "en_US"
+
+// FakeLocale holds the dictionaries of fake values of a locale. Empty dictionaries fall back to those of en_US.
+type FakeLocale struct {
+	FirstNames []string
+	LastNames  []string
+	// NameFormat formats names from "{first}" and "{last}", e.g. "{first} {last}"
+	NameFormat string
+	// EmailNames are the ASCII names of the local parts of email addresses, the first and last names by default
+	EmailNames   []string
+	EmailDomains []string
+	// PhoneFormats are formats of phone numbers, each "#" is replaced with a digit
+	PhoneFormats []string
+	Streets      []string
+	Cities       []string
+	// AddressFormats are formats of addresses from "{street}" and "{city}", each "#" is replaced with a digit
+	AddressFormats []string
+}
+
+// FakePolicy configures the substitution of values with realistic fake values. The fake of a value is picked with a
+// seed derived from the HMAC-SHA256 of the key, the mask type and the value, so the same value always gets the same
+// fake with the same key, and fakes can't be linked back to values without the key.
+type FakePolicy struct {
+	// Key is the HMAC key seeding fakes. Without key, values are masked entirely.
+	Key []byte
+	// Locale selects the dictionaries of fake values, "en_US" by default. "zh_TW" is built in, and RegisterFakeLocale
+	// adds locales.
+	Locale string
+}
+
+var fakeLocalesMu sync.RWMutex
+
+// RegisterFakeLocale adds or replaces the dictionaries of fake values of a locale
+//
+// Example:
+//
+//	customMasker.RegisterFakeLocale("en_GB", customMasker.FakeLocale{
+//		FirstNames:   []string{"Oliver", "Amelia"},
+//		LastNames:    []string{"Smith", "Jones"},
+//		PhoneFormats: []string{"07700 900###"},
+//	})
+func RegisterFakeLocale(locale string, dictionaries FakeLocale) error {
+	if locale == "" {
+		return fmt.Errorf("%w: empty locale", ErrUnknownLocale)
+	}
+	fakeLocalesMu.Lock()
+	defer fakeLocalesMu.Unlock()
+	fakeLocales[locale] = dictionaries
+	return nil
+}
+
+// UpdateFakePolicy updates the policy used to generate fake values. It returns ErrUnknownLocale if the locale has no
+// dictionaries.
+func (m *Masker) UpdateFakePolicy(policy FakePolicy) error {
+	if policy.Locale == "" {
+		policy.Locale = defaultFakeLocale
+	}
+	if _, ok := fakeLocale(policy.Locale); !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownLocale, policy.Locale)
+	}
+	policy.Key = append([]byte(nil), policy.Key...)
+	m.fake = policy
+	return nil
+}
+
+// Fake replaces a value with a realistic fake value of a fake mask type: MFakeName, MFakeEmail, MFakePhone,
+// MFakeAddress or MFakeCreditCard. Fake card numbers keep the first digit, the length and the separators of the value
+// and have a valid Luhn check digit. Values are masked entirely if the fake policy has no key.
+//
+// Example:
+//
+//	input: Jane Doe
+//	output: Linda Walker
+//	input: dummy@dummy.com
+//	output: mark.harris27@example.org
+func (m *Masker) Fake(t Mtype, i string) string {
+	if i == "" {
+		return ""
+	}
+	if len(m.fake.Key) == 0 {
+		return m.MaskWithSpec(MaskSpec{}, i)
+	}
+	locale, ok := fakeLocale(m.fake.Locale)
+	if !ok {
+		locale, _ = fakeLocale(defaultFakeLocale)
+	}
+
+	mac := hmac.New(sha256.New, m.fake.Key)
+	mac.Write([]byte(t))
+	mac.Write([]byte{0})
+	mac.Write([]byte(i))
+	f := faker{locale: locale, rng: rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(mac.Sum(nil)))))}
+
+	switch t {
+	case MFakeName:
+		return f.name()
+	case MFakeEmail:
+		return f.email()
+	case MFakePhone:
+		return f.digits(f.pick(locale.PhoneFormats))
+	case MFakeAddress:
+		return f.address()
+	case MFakeCreditCard:
+		return f.creditCard(i)
+	default:
+		return m.MaskWithSpec(MaskSpec{}, i)
+	}
+}
+
+// fakeLocale returns the dictionaries of a locale completed with those of en_US
+func fakeLocale(locale string) (FakeLocale, bool) {
+	if locale == "" {
+		locale = defaultFakeLocale
+	}
+	fakeLocalesMu.RLock()
+	defer fakeLocalesMu.RUnlock()
+	l, ok := fakeLocales[locale]
+	if !ok {
+		return FakeLocale{}, false
+	}
+	fallback := fakeLocales[defaultFakeLocale]
+	for _, field := range []struct{ dst, src *[]string }{
+		{&l.FirstNames, &fallback.FirstNames},
+		{&l.LastNames, &fallback.LastNames},
+		{&l.EmailDomains, &fallback.EmailDomains},
+		{&l.PhoneFormats, &fallback.PhoneFormats},
+		{&l.Streets, &fallback.Streets},
+		{&l.Cities, &fallback.Cities},
+		{&l.AddressFormats, &fallback.AddressFormats},
+	} {
+		if len(*field.dst) == 0 {
+			*field.dst = *field.src
+		}
+	}
+	if l.NameFormat == "" {
+		l.NameFormat = fallback.NameFormat
+	}
+	return l, true
+}
+
+// faker picks fake values from the dictionaries of a locale with a seeded source
+type faker struct {
+	locale FakeLocale
+	rng    *rand.Rand
+}
+
+func (f faker) pick(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+	return words[f.rng.Intn(len(words))]
+}
+
+// digits replaces each "#" of the format with a digit. Numbers don't start with 0, unless the format starts them.
+func (f faker) digits(format string) string {
+	var b strings.Builder
+	previous := ' '
+	for _, c := range format {
+		if c == '#' {
+			if previous >= '0' && previous <= '9' {
+				c = rune('0' + f.rng.Intn(10))
+			} else {
+				c = rune('1' + f.rng.Intn(9))
+			}
+		}
+		b.WriteRune(c)
+		previous = c
+	}
+	return b.String()
+}
+
+func (f faker) name() string {
+	return strings.NewReplacer("{first}", f.pick(f.locale.FirstNames), "{last}", f.pick(f.locale.LastNames)).
+		Replace(f.locale.NameFormat)
+}
+
+func (f faker) email() string {
+	var first, last string
+	if len(f.locale.EmailNames) > 0 {
+		first, last = f.pick(f.locale.EmailNames), f.pick(f.locale.EmailNames)
+	} else {
+		first, last = f.pick(f.locale.FirstNames), f.pick(f.locale.LastNames)
+	}
+	local := strings.ToLower(strings.Replace(first+"."+last, " ", "", -1))
+	return local + f.digits("##") + "@" + f.pick(f.locale.EmailDomains)
+}
+
+func (f faker) address() string {
+	format := f.pick(f.locale.AddressFormats)
+	// cities may hold "#" too, e.g. in postal codes
+	format = strings.NewReplacer("{street}", f.pick(f.locale.Streets), "{city}", f.pick(f.locale.Cities)).Replace(format)
+	return f.digits(format)
+}
+
+// creditCard generates a card number with the first digit, the length and the separators of the value, and a valid
+// Luhn check digit
+func (f faker) creditCard(i string) string {
+	var positions []int
+	for idx := 0; idx < len(i); idx++ {
+		if i[idx] >= '0' && i[idx] <= '9' {
+			positions = append(positions, idx)
+		}
+	}
+	if len(positions) < 2 {
+		return f.digits(strings.Repeat("#", 16))
+	}
+	digits := make([]int, len(positions)-1)
+	digits[0] = int(i[positions[0]] - '0')
+	for idx := 1; idx < len(digits); idx++ {
+		digits[idx] = f.rng.Intn(10)
+	}
+	digits = append(digits, luhnCheckDigit(digits))
+
+	b := []byte(i)
+	for idx, pos := range positions {
+		b[pos] = byte('0' + digits[idx])
+	}
+	return string(b)
+}
@@ -0,0 +1,56 @@
+package customMasker
+
+// fakeLocales maps locales to the dictionaries of fake values
+var fakeLocales = map[string]FakeLocale{
+	"en_US": {
+		FirstNames: []string{
+			"James", "Mary", "John", "Patricia", "Robert", "Jennifer", "Michael", "Linda", "William", "Elizabeth",
+			"David", "Barbara", "Richard", "Susan", "Joseph", "Jessica", "Thomas", "Sarah", "Charles", "Karen",
+			"Daniel", "Nancy", "Matthew", "Lisa", "Anthony", "Betty", "Mark", "Margaret", "Steven", "Emily",
+		},
+		LastNames: []string{
+			"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Rodriguez", "Martinez",
+			"Hernandez", "Lopez", "Wilson", "Anderson", "Thomas", "Taylor", "Moore", "Jackson", "Martin", "Lee",
+			"Thompson", "White", "Harris", "Clark", "Lewis", "Robinson", "Walker", "Young", "Allen", "King",
+		},
+		NameFormat:   "{first} {last}",
+		EmailDomains: []string{"example.com", "example.net", "example.org"},
+		// 555-0100 to 555-0199 are reserved for fictional use
+		PhoneFormats: []string{"(2##) 555-01##", "(3##) 555-01##", "(4##) 555-01##", "(6##) 555-01##", "(7##) 555-01##"},
+		Streets: []string{
+			"Main Street", "Oak Avenue", "Maple Drive", "Cedar Lane", "Pine Street", "Elm Street", "Washington Avenue",
+			"Lake View Road", "Hill Street", "Park Avenue", "Sunset Boulevard", "River Road", "Church Street",
+		},
+		Cities: []string{
+			"Springfield, IL 627##", "Riverside, CA 925##", "Franklin, TN 370##", "Greenville, SC 296##",
+			"Bristol, CT 060##", "Clinton, IA 527##", "Fairview, OR 970##", "Madison, WI 537##", "Salem, MA 019##",
+		},
+		AddressFormats: []string{"### {street}, {city}", "#### {street}, {city}", "## {street} Apt. #, {city}"},
+	},
+	"zh_TW": {
+		FirstNames: []string{
+			"家豪", "志明", "俊傑", "建宏", "冠宇", "承翰", "宗翰", "怡君", "雅婷", "淑芬",
+			"佳穎", "詩涵", "欣怡", "美玲", "婉婷", "宜蓁", "柏翰", "彥廷", "品妤", "思妤",
+		},
+		LastNames: []string{
+			"陳", "林", "黃", "張", "李", "王", "吳", "劉", "蔡", "楊",
+			"許", "鄭", "謝", "郭", "洪", "曾", "邱", "廖", "賴", "周",
+		},
+		NameFormat: "{last}{first}",
+		EmailNames: []string{
+			"chen", "lin", "huang", "chang", "li", "wang", "wu", "liu", "tsai", "yang",
+			"chiahao", "chihming", "chunchieh", "yichun", "yating", "shufen", "chiaying", "hsinyi", "meiling", "pohan",
+		},
+		EmailDomains: []string{"example.com", "example.net", "example.org"},
+		PhoneFormats: []string{"09##-###-###", "09########", "(02) 2###-####", "(04) 2###-####", "(07) ###-####"},
+		Streets: []string{
+			"中山路", "中正路", "民生路", "民權路", "信義路", "和平東路", "復興南路", "忠孝東路", "光復路", "成功路",
+			"建國路", "自由路", "文化路", "公園路", "博愛路",
+		},
+		Cities: []string{
+			"臺北市大安區", "臺北市信義區", "新北市板橋區", "桃園市中壢區", "臺中市西屯區", "臺南市東區",
+			"高雄市苓雅區", "新竹市東區", "基隆市仁愛區", "嘉義市西區",
+		},
+		AddressFormats: []string{"{city}{street}#段##號", "{city}{street}###號#樓", "{city}{street}#段###巷##號"},
+	},
+}
@@ -0,0 +1,103 @@
+package customMasker
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var testFakeKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestMasker_Fake(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale string
+		t      Mtype
+		input  string
+		want   *regexp.Regexp
+	}{
+		{name: "Name", t: MFakeName, input: "Jane Doe", want: regexp.MustCompile(`^[A-Z][a-z]+ [A-Z][a-z]+$`)},
+		{name: "Email", t: MFakeEmail, input: "dummy@dummy.com", want: regexp.MustCompile(`^[a-z]+\.[a-z]+[1-9]\d@example\.(com|net|org)$`)},
+		{name: "Phone", t: MFakePhone, input: "+1 212 555 0123", want: regexp.MustCompile(`^\([2-7]\d\d\) 555-01\d\d$`)},
+		{name: "Address", t: MFakeAddress, input: "1 Infinite Loop, Cupertino", want: regexp.MustCompile(`^[1-9][\d A-Za-z.]+, [A-Za-z ]+, [A-Z]{2} \d{5}$`)},
+		{name: "Credit Card", t: MFakeCreditCard, input: "4111-1111-1111-1111", want: regexp.MustCompile(`^4\d{3}-\d{4}-\d{4}-\d{4}$`)},
+		{name: "Credit Card Without Separators", t: MFakeCreditCard, input: "378282246310005", want: regexp.MustCompile(`^3\d{14}$`)},
+		{name: "Taiwan Name", locale: "zh_TW", t: MFakeName, input: "王小明", want: regexp.MustCompile(`^\p{Han}{3}$`)},
+		{name: "Taiwan Email", locale: "zh_TW", t: MFakeEmail, input: "dummy@dummy.com", want: regexp.MustCompile(`^[a-z]+\.[a-z]+[1-9]\d@example\.(com|net|org)$`)},
+		{name: "Taiwan Phone", locale: "zh_TW", t: MFakePhone, input: "0978978978", want: regexp.MustCompile(`^(09\d{2}-?\d{3}-?\d{3}|\(0\d\) [1-9]\d{2,3}-\d{4})$`)},
+		{name: "Taiwan Address", locale: "zh_TW", t: MFakeAddress, input: "台北市內湖區內湖路一段737巷1號1樓", want: regexp.MustCompile(`^\p{Han}+[市]\p{Han}+區\p{Han}+路.*號`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMasker()
+			if err := m.UpdateFakePolicy(FakePolicy{Key: testFakeKey, Locale: tt.locale}); err != nil {
+				t.Fatalf("Masker.UpdateFakePolicy() error = %v", err)
+			}
+			got := m.String(tt.t, tt.input, "")
+			if !tt.want.MatchString(got) {
+				t.Errorf("Masker.String() = %v, want match of %v", got, tt.want)
+			}
+			if again := m.String(tt.t, tt.input, ""); again != got {
+				t.Errorf("Masker.String() = %v, then %v, want the same fake", got, again)
+			}
+			if tt.t == MFakeCreditCard && !LuhnValid(got) {
+				t.Errorf("Masker.String() = %v, want a valid Luhn check digit", got)
+			}
+		})
+	}
+}
+
+func TestMasker_FakeSeeds(t *testing.T) {
+	m := NewMasker()
+	if err := m.UpdateFakePolicy(FakePolicy{Key: testFakeKey}); err != nil {
+		t.Fatalf("Masker.UpdateFakePolicy() error = %v", err)
+	}
+	other := NewMasker()
+	if err := other.UpdateFakePolicy(FakePolicy{Key: []byte("another key")}); err != nil {
+		t.Fatalf("Masker.UpdateFakePolicy() error = %v", err)
+	}
+	fakes := map[string]bool{}
+	for _, name := range []string{"Jane Doe", "John Doe", "Mary Major", "Richard Roe", "Tom Thumb"} {
+		fakes[m.Fake(MFakeEmail, name)] = true
+	}
+	if len(fakes) < 4 {
+		t.Errorf("Masker.Fake() = %v, want different values to get different fakes", fakes)
+	}
+	if m.Fake(MFakeEmail, "Jane Doe") == other.Fake(MFakeEmail, "Jane Doe") {
+		t.Errorf("Masker.Fake() gives the same fake with different keys")
+	}
+}
+
+func TestMasker_FakeLocales(t *testing.T) {
+	m := NewMasker()
+	if got, want := m.Fake(MFakeName, "Jane Doe"), "********"; got != want {
+		t.Errorf("Masker.Fake() = %v, want %v without key", got, want)
+	}
+	if err := m.UpdateFakePolicy(FakePolicy{Key: testFakeKey, Locale: "xx_XX"}); !errors.Is(err, ErrUnknownLocale) {
+		t.Errorf("Masker.UpdateFakePolicy() error = %v, want ErrUnknownLocale", err)
+	}
+
+	if err := RegisterFakeLocale("en_GB", FakeLocale{
+		FirstNames:   []string{"Oliver"},
+		LastNames:    []string{"Smith"},
+		PhoneFormats: []string{"07700 900###"},
+	}); err != nil {
+		t.Fatalf("RegisterFakeLocale() error = %v", err)
+	}
+	if err := m.UpdateFakePolicy(FakePolicy{Key: testFakeKey, Locale: "en_GB"}); err != nil {
+		t.Fatalf("Masker.UpdateFakePolicy() error = %v", err)
+	}
+	if got, want := m.Fake(MFakeName, "Jane Doe"), "Oliver Smith"; got != want {
+		t.Errorf("Masker.Fake() = %v, want %v", got, want)
+	}
+	if got := m.Fake(MFakePhone, "0978978978"); !strings.HasPrefix(got, "07700 900") {
+		t.Errorf("Masker.Fake() = %v, want a phone of the registered locale", got)
+	}
+	if got := m.Fake(MFakeEmail, "dummy@dummy.com"); !strings.HasPrefix(got, "oliver.smith") {
+		t.Errorf("Masker.Fake() = %v, want the email names of the registered locale", got)
+	}
+	if got := m.Fake(MFakeAddress, "1 Infinite Loop"); got == "" || got == "1 Infinite Loop" {
+		t.Errorf("Masker.Fake() = %v, want en_US addresses for missing dictionaries", got)
+	}
+}
@@ -0,0 +1,158 @@
+package customMasker
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+)
+
+// ff1Rounds is the number of Feistel rounds of FF1
+const ff1Rounds = 10
+
+// errFF1Domain is returned when a numeral string is too short for the domain of FF1
+var errFF1Domain = errors.New("value too short for format-preserving encryption")
+
+// ff1 implements the FF1 format-preserving encryption mode of NIST SP 800-38G over numeral strings of a radix
+type ff1 struct {
+	block  cipher.Block
+	radix  int
+	tweak  []byte
+	minLen int
+}
+
+func newFF1(key []byte, tweak []byte, radix int) (*ff1, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	// the domain must have at least a million values
+	minLen := int(math.Ceil(6 / math.Log10(float64(radix))))
+	if minLen < 2 {
+		minLen = 2
+	}
+	return &ff1{block: block, radix: radix, tweak: append([]byte(nil), tweak...), minLen: minLen}, nil
+}
+
+func (f *ff1) encrypt(x []int) ([]int, error) {
+	return f.crypt(x, true)
+}
+
+func (f *ff1) decrypt(x []int) ([]int, error) {
+	return f.crypt(x, false)
+}
+
+func (f *ff1) crypt(x []int, encrypt bool) ([]int, error) {
+	n := len(x)
+	if n < f.minLen {
+		return nil, errFF1Domain
+	}
+	u := n / 2
+	v := n - u
+	a := append([]int(nil), x[:u]...)
+	b := append([]int(nil), x[u:]...)
+
+	radix := big.NewInt(int64(f.radix))
+	byteLen := int(math.Ceil(math.Ceil(float64(v)*math.Log2(float64(f.radix))) / 8))
+	d := 4*((byteLen+3)/4) + 4
+
+	p := make([]byte, aes.BlockSize)
+	p[0], p[1], p[2] = 1, 2, 1
+	p[3], p[4], p[5] = byte(f.radix>>16), byte(f.radix>>8), byte(f.radix)
+	p[6], p[7] = 10, byte(u)
+	binary.BigEndian.PutUint32(p[8:], uint32(n))
+	binary.BigEndian.PutUint32(p[12:], uint32(len(f.tweak)))
+
+	t := len(f.tweak)
+	pad := ((-t-byteLen-1)%16 + 16) % 16
+	q := make([]byte, t+pad+1+byteLen)
+	copy(q, f.tweak)
+
+	modU := new(big.Int).Exp(radix, big.NewInt(int64(u)), nil)
+	modV := new(big.Int).Exp(radix, big.NewInt(int64(v)), nil)
+
+	for step := 0; step < ff1Rounds; step++ {
+		i := step
+		if !encrypt {
+			i = ff1Rounds - 1 - step
+		}
+		// the round function hashes the half which isn't modified in this round
+		source := b
+		if !encrypt {
+			source = a
+		}
+		q[t+pad] = byte(i)
+		num := numeralsValue(source, radix)
+		numBytes := num.Bytes()
+		for idx := range q[t+pad+1:] {
+			q[t+pad+1+idx] = 0
+		}
+		copy(q[len(q)-len(numBytes):], numBytes)
+
+		y := new(big.Int).SetBytes(f.roundOutput(p, q, d))
+		m, mod := u, modU
+		if i%2 == 1 {
+			m, mod = v, modV
+		}
+
+		if encrypt {
+			c := numeralsValue(a, radix)
+			c.Add(c, y).Mod(c, mod)
+			a, b = b, valueNumerals(c, radix, m)
+		} else {
+			c := numeralsValue(b, radix)
+			c.Sub(c, y).Mod(c, mod)
+			b, a = a, valueNumerals(c, radix, m)
+		}
+	}
+	return append(a, b...), nil
+}
+
+// roundOutput computes the d bytes of S from the CBC-MAC R of P || Q
+func (f *ff1) roundOutput(p []byte, q []byte, d int) []byte {
+	r := make([]byte, aes.BlockSize)
+	for _, data := range [][]byte{p, q} {
+		for off := 0; off < len(data); off += aes.BlockSize {
+			for idx := 0; idx < aes.BlockSize; idx++ {
+				r[idx] ^= data[off+idx]
+			}
+			f.block.Encrypt(r, r)
+		}
+	}
+
+	s := append([]byte(nil), r...)
+	block := make([]byte, aes.BlockSize)
+	for j := 1; len(s) < d; j++ {
+		copy(block, r)
+		counter := make([]byte, aes.BlockSize)
+		binary.BigEndian.PutUint64(counter[8:], uint64(j))
+		for idx := range block {
+			block[idx] ^= counter[idx]
+		}
+		f.block.Encrypt(block, block)
+		s = append(s, block...)
+	}
+	return s[:d]
+}
+
+func numeralsValue(x []int, radix *big.Int) *big.Int {
+	value := new(big.Int)
+	for _, numeral := range x {
+		value.Mul(value, radix)
+		value.Add(value, big.NewInt(int64(numeral)))
+	}
+	return value
+}
+
+func valueNumerals(value *big.Int, radix *big.Int, m int) []int {
+	x := make([]int, m)
+	rest := new(big.Int).Set(value)
+	digit := new(big.Int)
+	for idx := m - 1; idx >= 0; idx-- {
+		rest.DivMod(rest, radix, digit)
+		x[idx] = int(digit.Int64())
+	}
+	return x
+}
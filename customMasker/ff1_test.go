@@ -0,0 +1,57 @@
+package customMasker
+
+import (
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestFF1 checks the samples of the FF1 examples published by NIST
+func TestFF1(t *testing.T) {
+	const alphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+	tests := []struct {
+		name  string
+		key   string
+		tweak string
+		radix int
+		plain string
+		want  string
+	}{
+		{name: "Sample 1", key: "2B7E151628AED2A6ABF7158809CF4F3C", radix: 10, plain: "0123456789", want: "2433477484"},
+		{name: "Sample 2", key: "2B7E151628AED2A6ABF7158809CF4F3C", tweak: "39383736353433323130", radix: 10, plain: "0123456789", want: "6124200773"},
+		{name: "Sample 3", key: "2B7E151628AED2A6ABF7158809CF4F3C", tweak: "3737373770717273373737", radix: 36, plain: "0123456789abcdefghi", want: "a9tv40mll9kdu509eum"},
+		{name: "Sample 4", key: "2B7E151628AED2A6ABF7158809CF4F3CEF4359D8D580AA4F", radix: 10, plain: "0123456789", want: "2830668132"},
+		{name: "Sample 7", key: "2B7E151628AED2A6ABF7158809CF4F3CEF4359D8D580AA4F7F036D6F04FC6A94", radix: 10, plain: "0123456789", want: "6657667009"},
+		{name: "Sample 9", key: "2B7E151628AED2A6ABF7158809CF4F3CEF4359D8D580AA4F7F036D6F04FC6A94", tweak: "3737373770717273373737", radix: 36, plain: "0123456789abcdefghi", want: "xs8a0azh2avyalyzuwd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, _ := hex.DecodeString(tt.key)
+			tweak, _ := hex.DecodeString(tt.tweak)
+			f, err := newFF1(key, tweak, tt.radix)
+			if err != nil {
+				t.Fatalf("newFF1() error = %v", err)
+			}
+			var plain []int
+			for _, c := range tt.plain {
+				plain = append(plain, strings.IndexRune(alphabet, c))
+			}
+			encrypted, err := f.encrypt(plain)
+			if err != nil {
+				t.Fatalf("ff1.encrypt() error = %v", err)
+			}
+			var got strings.Builder
+			for _, numeral := range encrypted {
+				got.WriteByte(alphabet[numeral])
+			}
+			if got.String() != tt.want {
+				t.Errorf("ff1.encrypt() = %v, want %v", got.String(), tt.want)
+			}
+			decrypted, err := f.decrypt(encrypted)
+			if err != nil || !reflect.DeepEqual(decrypted, plain) {
+				t.Errorf("ff1.decrypt() = %v, %v, want %v", decrypted, err, plain)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package customMasker
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Generalizer coarsens the values of quasi-identifiers, like dates of birth or postal codes, so they can still be
+// analyzed without identifying people
+type Generalizer interface {
+	// GeneralizeString coarsens a string, e.g. "1987-06-15" to "1987". ok is false if the string can't be parsed.
+	GeneralizeString(i string) (generalized string, ok bool)
+	// GeneralizeValue coarsens a typed value, like an int, a float or a time.Time, into a value of the same type. ok is
+	// false for types the generalizer doesn't support.
+	GeneralizeValue(v interface{}) (generalized interface{}, ok bool)
+}
+
+// Generalizers of the built-in generalization mask types
+var builtinGeneralizers = map[Mtype]Generalizer{
+	MYear:         YearGeneralizer(),
+	MAgeBand:      RangeGeneralizer(10),
+	MPostalPrefix: PrefixGeneralizer(3),
+	MGeoGrid:      GridGeneralizer(0.01),
+}
+
+// RegisterGeneralizer registers a user-defined mask type generalizing values, e.g. to configure the width of ranges
+// per field. Filters using the mask type generalize strings and typed values. Noise generalizers with invalid
+// parameters return ErrInvalidNoise.
+//
+// Example:
+//
+//	masker.RegisterGeneralizer(customMasker.Mtype("salary"), customMasker.RangeGeneralizer(10000))
+func (m *Masker) RegisterGeneralizer(t Mtype, g Generalizer) error {
+	if g == nil {
+		return fmt.Errorf("%w: %q", ErrInvalidMaskType, t)
+	}
+	if v, ok := g.(interface{ validate() error }); ok {
+		if err := v.validate(); err != nil {
+			return err
+		}
+	}
+	if err := m.registerBound(t, func(m *Masker, i string) string {
+		return m.generalize(g, i)
+	}); err != nil {
+		return err
+	}
+	m.registry.registerValue(t, g.GeneralizeValue)
+	return nil
+}
+
+// Generalize coarsens a value of a built-in generalization mask type: MYear, MAgeBand, MPostalPrefix or MGeoGrid.
+// Values which can't be parsed are masked entirely.
+//
+// Example:
+//
+//	input: 1987-06-15 (MYear)
+//	output: 1987
+//	input: 37 (MAgeBand)
+//	output: 30-39
+//	input: 94107 (MPostalPrefix)
+//	output: 941**
+//	input: 37.7749,-122.4194 (MGeoGrid)
+//	output: 37.77,-122.42
+func (m *Masker) Generalize(t Mtype, i string) string {
+	g, ok := builtinGeneralizers[t]
+	if !ok {
+		return m.MaskWithSpec(MaskSpec{}, i)
+	}
+	return m.generalize(g, i)
+}
+
+// MaskValue masks a typed value of a generalization or reference mask type, built-in or registered on this masker. ok
+// is false for other mask types, and for types the mask type doesn't support.
+func (m *Masker) MaskValue(t Mtype, v interface{}) (interface{}, bool) {
+	if g, ok := builtinGeneralizers[t]; ok {
+		return g.GeneralizeValue(v)
+	}
+	if t == MReference {
+		return m.referenceValue(string(MReference), v)
+	}
+	if m.registry == nil {
+		return nil, false
+	}
+	fn, ok := m.registry.lookupValue(t)
+	if !ok {
+		return nil, false
+	}
+	return fn(v)
+}
+
+func (m *Masker) generalize(g Generalizer, i string) string {
+	if i == "" {
+		return ""
+	}
+	generalized, ok := g.GeneralizeString(i)
+	if !ok {
+		return m.MaskWithSpec(MaskSpec{}, i)
+	}
+	return generalized
+}
+
+var (
+	yearRegex        = regexp.MustCompile(`(?:^|\D)(\d{4})(?:\D|$)`)
+	compactDateRegex = regexp.MustCompile(`^(\d{4})\d{4}$`)
+	numberRegex      = regexp.MustCompile(`-?\d+(?:\.\d+)?`)
+)
+
+type yearGeneralizer struct{}
+
+// YearGeneralizer generalizes dates to their year. Strings are replaced with the year, and time.Time values with
+// January 1st of their year.
+func YearGeneralizer() Generalizer {
+	return yearGeneralizer{}
+}
+
+func (yearGeneralizer) GeneralizeString(i string) (string, bool) {
+	i = strings.TrimSpace(i)
+	if match := compactDateRegex.FindStringSubmatch(i); match != nil {
+		return match[1], true
+	}
+	if match := yearRegex.FindStringSubmatch(i); match != nil {
+		return match[1], true
+	}
+	return "", false
+}
+
+func (yearGeneralizer) GeneralizeValue(v interface{}) (interface{}, bool) {
+	switch value := v.(type) {
+	case time.Time:
+		if value.IsZero() {
+			return value, true
+		}
+		return time.Date(value.Year(), time.January, 1, 0, 0, 0, 0, value.Location()), true
+	default:
+		// integers are already years
+		kind := reflect.ValueOf(v).Kind()
+		return v, kind >= reflect.Int && kind <= reflect.Uint64
+	}
+}
+
+type rangeGeneralizer struct {
+	width float64
+}
+
+// RangeGeneralizer generalizes numbers to ranges of the width, e.g. ages to 10-year bands or salaries to ranges of
+// 10000. Strings are replaced with the range, like "30-39", and typed numbers with the lower bound of their range.
+func RangeGeneralizer(width float64) Generalizer {
+	return rangeGeneralizer{width: width}
+}
+
+func (g rangeGeneralizer) GeneralizeString(i string) (string, bool) {
+	number := strings.Replace(strings.TrimSpace(i), ",", "", -1)
+	v, err := strconv.ParseFloat(number, 64)
+	if err != nil || g.width <= 0 {
+		return "", false
+	}
+	lower := g.lower(v)
+	if !strings.ContainsAny(number, ".eE") && g.width == math.Trunc(g.width) {
+		return strconv.FormatFloat(lower, 'f', -1, 64) + "-" + strconv.FormatFloat(lower+g.width-1, 'f', -1, 64), true
+	}
+	return strconv.FormatFloat(lower, 'f', -1, 64) + "-" + strconv.FormatFloat(lower+g.width, 'f', -1, 64), true
+}
+
+func (g rangeGeneralizer) GeneralizeValue(v interface{}) (interface{}, bool) {
+	if g.width <= 0 {
+		return nil, false
+	}
+	return mapNumber(v, g.lower)
+}
+
+func (g rangeGeneralizer) lower(v float64) float64 {
+	return math.Floor(v/g.width) * g.width
+}
+
+type prefixGeneralizer struct {
+	keep int
+}
+
+// PrefixGeneralizer generalizes codes, like postal codes, to their first letters and digits. Strings keep the first
+// characters and mask the letters and digits of the rest, like "941**", and typed integers keep the first digits and
+// zero the others, like 94100.
+func PrefixGeneralizer(keep int) Generalizer {
+	return prefixGeneralizer{keep: keep}
+}
+
+func (g prefixGeneralizer) GeneralizeString(i string) (string, bool) {
+	return MaskSpec{KeepFirst: g.keep, Classes: CLetters | CDigits}.Mask(i), true
+}
+
+func (g prefixGeneralizer) GeneralizeValue(v interface{}) (interface{}, bool) {
+	return mapNumber(v, func(f float64) float64 {
+		digits := len(strconv.FormatFloat(math.Abs(math.Trunc(f)), 'f', 0, 64))
+		if digits <= g.keep {
+			return f
+		}
+		scale := math.Pow(10, float64(digits-g.keep))
+		return math.Trunc(f/scale) * scale
+	})
+}
+
+type gridGeneralizer struct {
+	cell float64
+}
+
+// GridGeneralizer generalizes coordinates to the south-west corner of their cell in a grid of cell degrees, e.g.
+// 0.01 for cells of about 1 km. Strings snap every number, like the latitude and the longitude of
+// "37.7749,-122.4194", and typed numbers are snapped themselves.
+func GridGeneralizer(cell float64) Generalizer {
+	return gridGeneralizer{cell: cell}
+}
+
+func (g gridGeneralizer) GeneralizeString(i string) (string, bool) {
+	if g.cell <= 0 || !numberRegex.MatchString(i) {
+		return "", false
+	}
+	decimals := 0
+	if g.cell < 1 {
+		decimals = int(math.Ceil(-math.Log10(g.cell) - 1e-9))
+	}
+	return numberRegex.ReplaceAllStringFunc(i, func(number string) string {
+		v, _ := strconv.ParseFloat(number, 64)
+		return strconv.FormatFloat(g.snap(v), 'f', decimals, 64)
+	}), true
+}
+
+func (g gridGeneralizer) GeneralizeValue(v interface{}) (interface{}, bool) {
+	if g.cell <= 0 {
+		return nil, false
+	}
+	return mapNumber(v, g.snap)
+}
+
+func (g gridGeneralizer) snap(v float64) float64 {
+	// round before flooring so values on a line of the grid don't fall into the cell below
+	return math.Floor(math.Round(v/g.cell*1e9)/1e9) * g.cell
+}
+
+// mapNumber applies fn to a typed number and converts the result back to the type of the number
+func mapNumber(v interface{}, fn func(float64) float64) (interface{}, bool) {
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return convertNumber(fn(float64(value.Int())), value.Type()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return convertNumber(fn(float64(value.Uint())), value.Type()), true
+	case reflect.Float32, reflect.Float64:
+		return convertNumber(fn(value.Float()), value.Type()), true
+	default:
+		return nil, false
+	}
+}
+
+// convertNumber converts f to the numeric type t. Integers are truncated and clamped to the range of t instead of
+// wrapping around, so a perturbed int8 near 127 stays near 127.
+func convertNumber(f float64, t reflect.Type) interface{} {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		max := int64(uint64(1)<<(t.Bits()-1) - 1)
+		limit := math.Ldexp(1, t.Bits()-1)
+		n := int64(0)
+		switch {
+		case f >= limit:
+			n = max
+		case f < -limit:
+			n = ^max
+		case !math.IsNaN(f):
+			n = int64(f)
+		}
+		return reflect.ValueOf(n).Convert(t).Interface()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := uint64(0)
+		switch {
+		case f >= math.Ldexp(1, t.Bits()):
+			n = math.MaxUint64 >> (64 - t.Bits())
+		case f > 0:
+			n = uint64(f)
+		}
+		return reflect.ValueOf(n).Convert(t).Interface()
+	default:
+		return reflect.ValueOf(f).Convert(t).Interface()
+	}
+}
@@ -0,0 +1,95 @@
+package customMasker
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMasker_Generalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		t     Mtype
+		input string
+		want  string
+	}{
+		{name: "Year Of Date", t: MYear, input: "1987-06-15", want: "1987"},
+		{name: "Year Of Timestamp", t: MYear, input: "1987-06-15T08:30:00Z", want: "1987"},
+		{name: "Year Of Day First Date", t: MYear, input: "15/06/1987", want: "1987"},
+		{name: "Year Of Compact Date", t: MYear, input: "19870615", want: "1987"},
+		{name: "Invalid Date", t: MYear, input: "June", want: "****"},
+		{name: "Age Band", t: MAgeBand, input: "37", want: "30-39"},
+		{name: "Age Band Lower Bound", t: MAgeBand, input: "40", want: "40-49"},
+		{name: "Fractional Age", t: MAgeBand, input: "37.5", want: "30-40"},
+		{name: "Invalid Age", t: MAgeBand, input: "old", want: "***"},
+		{name: "Postal Prefix", t: MPostalPrefix, input: "94107", want: "941**"},
+		{name: "Postal Prefix With Space", t: MPostalPrefix, input: "SW1A 1AA", want: "SW1* ***"},
+		{name: "Geo Grid", t: MGeoGrid, input: "37.7749,-122.4194", want: "37.77,-122.42"},
+		{name: "Geo Grid On Line", t: MGeoGrid, input: "25.03, 121.56", want: "25.03, 121.56"},
+		{name: "Invalid Coordinates", t: MGeoGrid, input: "north", want: "*****"},
+		{name: "Empty", t: MYear, input: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewMasker().String(tt.t, tt.input, ""); got != tt.want {
+				t.Errorf("Masker.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type testAge int
+
+func TestMasker_MaskValue(t *testing.T) {
+	m := NewMasker()
+	if err := m.RegisterGeneralizer(Mtype("salary"), RangeGeneralizer(10000)); err != nil {
+		t.Fatalf("Masker.RegisterGeneralizer() error = %v", err)
+	}
+	taipei := time.FixedZone("CST", 8*60*60)
+	tests := []struct {
+		name   string
+		t      Mtype
+		input  interface{}
+		want   interface{}
+		wantOK bool
+	}{
+		{name: "Year Of Time", t: MYear, input: time.Date(1987, 6, 15, 8, 30, 0, 0, taipei), want: time.Date(1987, 1, 1, 0, 0, 0, 0, taipei), wantOK: true},
+		{name: "Year Of Int", t: MYear, input: 1987, want: 1987, wantOK: true},
+		{name: "Age Band Of Int", t: MAgeBand, input: 37, want: 30, wantOK: true},
+		{name: "Age Band Of Named Int", t: MAgeBand, input: testAge(37), want: testAge(30), wantOK: true},
+		{name: "Age Band Of Uint8", t: MAgeBand, input: uint8(65), want: uint8(60), wantOK: true},
+		{name: "Postal Prefix Of Int", t: MPostalPrefix, input: 94107, want: 94100, wantOK: true},
+		{name: "Geo Grid Of Float", t: MGeoGrid, input: -122.4194, want: -122.42, wantOK: true},
+		{name: "Registered Range", t: Mtype("salary"), input: 52345.5, want: 50000.0, wantOK: true},
+		{name: "Unsupported Type", t: MAgeBand, input: []int{37}},
+		{name: "Not A Generalization", t: MEmail, input: 37},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := m.MaskValue(tt.t, tt.input)
+			if ok != tt.wantOK {
+				t.Fatalf("Masker.MaskValue() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if tt.wantOK && tt.t == MGeoGrid {
+				if diff := got.(float64) - tt.want.(float64); diff > 1e-9 || diff < -1e-9 {
+					t.Errorf("Masker.MaskValue() = %v, want %v", got, tt.want)
+				}
+				return
+			}
+			if tt.wantOK && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Masker.MaskValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	if got, want := m.String(Mtype("salary"), "52,345", ""), "50000-59999"; got != want {
+		t.Errorf("Masker.String() = %v, want %v", got, want)
+	}
+	if err := m.RegisterGeneralizer(MYear, RangeGeneralizer(5)); !errors.Is(err, ErrDuplicateMaskType) {
+		t.Errorf("Masker.RegisterGeneralizer() error = %v, want ErrDuplicateMaskType", err)
+	}
+	if err := m.RegisterGeneralizer(Mtype("nil"), nil); !errors.Is(err, ErrInvalidMaskType) {
+		t.Errorf("Masker.RegisterGeneralizer() error = %v, want ErrInvalidMaskType", err)
+	}
+}
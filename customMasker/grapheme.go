@@ -0,0 +1,134 @@
+package customMasker
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// graphemes splits a string in user-perceived characters, following a subset of the extended grapheme cluster rules
+// of Unicode Standard Annex #29: CR LF, combining and spacing marks, prepended concatenation marks, emoji modifiers
+// and zero width joiner sequences, regional indicator pairs and Hangul syllables formed of jamo stay together. Indic
+// conjuncts (rule GB9c) aren't joined: their consonants are split after the virama.
+func graphemes(s string) []string {
+	if isASCII(s) {
+		clusters := make([]string, 0, len(s))
+		for i := 0; i < len(s); i++ {
+			if s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n' {
+				clusters = append(clusters, s[i:i+2])
+				i++
+				continue
+			}
+			clusters = append(clusters, s[i:i+1])
+		}
+		return clusters
+	}
+
+	var clusters []string
+	start := 0
+	var prev rune = -1
+	regionalIndicators := 0
+	for i, c := range s {
+		if prev >= 0 && graphemeBreak(prev, c, regionalIndicators) {
+			clusters = append(clusters, s[start:i])
+			start = i
+			regionalIndicators = 0
+		}
+		if isRegionalIndicator(c) {
+			regionalIndicators++
+		}
+		prev = c
+	}
+	if start < len(s) {
+		clusters = append(clusters, s[start:])
+	}
+	return clusters
+}
+
+// graphemeLen returns the number of user-perceived characters of a string
+func graphemeLen(s string) int {
+	if isASCII(s) {
+		return len(s)
+	}
+	return len(graphemes(s))
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf || s[i] == '\r' {
+			return false
+		}
+	}
+	return true
+}
+
+// graphemeBreak reports whether there is a grapheme cluster boundary between prev and c
+func graphemeBreak(prev, c rune, regionalIndicators int) bool {
+	switch {
+	case prev == '\r' && c == '\n':
+		return false
+	case isControl(prev) || isControl(c):
+		return true
+	case isHangulL(prev) && (isHangulL(c) || isHangulV(c) || isHangulLV(c) || isHangulLVT(c)):
+		return false
+	case (isHangulLV(prev) || isHangulV(prev)) && (isHangulV(c) || isHangulT(c)):
+		return false
+	case (isHangulLVT(prev) || isHangulT(prev)) && isHangulT(c):
+		return false
+	case isExtend(c) || c == zeroWidthJoiner:
+		return false
+	case isPrepend(prev):
+		return false
+	case prev == zeroWidthJoiner && isPictographic(c):
+		return false
+	case isRegionalIndicator(prev) && isRegionalIndicator(c):
+		return regionalIndicators%2 == 0
+	}
+	return true
+}
+
+const zeroWidthJoiner = '\u200d'
+
+func isControl(c rune) bool {
+	return c == '\r' || c == '\n' || (unicode.IsControl(c) && c != zeroWidthJoiner)
+}
+
+func isExtend(c rune) bool {
+	return unicode.In(c, unicode.Mn, unicode.Me, unicode.Mc) ||
+		(c >= 0x1F3FB && c <= 0x1F3FF) || // emoji modifiers
+		(c >= 0xE0020 && c <= 0xE007F) // tags
+}
+
+// isPrepend reports whether a character has the Prepend grapheme cluster break property, like the Arabic number sign
+func isPrepend(c rune) bool {
+	return (c >= 0x0600 && c <= 0x0605) || c == 0x06DD || c == 0x070F || c == 0x0890 || c == 0x0891 ||
+		c == 0x08E2 || c == 0x0D4E || c == 0x110BD || c == 0x110CD || c == 0x111C2 || c == 0x111C3 ||
+		c == 0x1193F || c == 0x11941 || c == 0x11A3A || (c >= 0x11A84 && c <= 0x11A89) || c == 0x11D46
+}
+
+func isPictographic(c rune) bool {
+	return unicode.Is(unicode.So, c) || (c >= 0x1F000 && c <= 0x1FAFF)
+}
+
+func isRegionalIndicator(c rune) bool {
+	return c >= 0x1F1E6 && c <= 0x1F1FF
+}
+
+func isHangulL(c rune) bool {
+	return (c >= 0x1100 && c <= 0x115F) || (c >= 0xA960 && c <= 0xA97C)
+}
+
+func isHangulV(c rune) bool {
+	return (c >= 0x1160 && c <= 0x11A7) || (c >= 0xD7B0 && c <= 0xD7C6)
+}
+
+func isHangulT(c rune) bool {
+	return (c >= 0x11A8 && c <= 0x11FF) || (c >= 0xD7CB && c <= 0xD7FB)
+}
+
+func isHangulLV(c rune) bool {
+	return c >= 0xAC00 && c <= 0xD7A3 && (c-0xAC00)%28 == 0
+}
+
+func isHangulLVT(c rune) bool {
+	return c >= 0xAC00 && c <= 0xD7A3 && (c-0xAC00)%28 != 0
+}
@@ -0,0 +1,34 @@
+package customMasker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		name string
+		i    string
+		want []string
+	}{
+		{name: "Empty Input", i: "", want: []string{}},
+		{name: "ASCII", i: "ab\r\nc", want: []string{"a", "b", "\r\n", "c"}},
+		{name: "Combining Marks", i: "Joséè", want: []string{"J", "o", "s", "é", "è"}},
+		{name: "Emoji Modifier", i: "a\U0001F44D\U0001F3FDb", want: []string{"a", "\U0001F44D\U0001F3FD", "b"}},
+		{name: "Zero Width Joiner Sequence", i: "\U0001F468\u200d\U0001F469\u200d\U0001F467!", want: []string{"\U0001F468\u200d\U0001F469\u200d\U0001F467", "!"}},
+		{name: "Regional Indicators", i: "\U0001F1F9\U0001F1FC\U0001F1EF\U0001F1F5\U0001F1FA", want: []string{"\U0001F1F9\U0001F1FC", "\U0001F1EF\U0001F1F5", "\U0001F1FA"}},
+		{name: "Hangul Jamo", i: "각나", want: []string{"각", "나"}},
+		{name: "Hangul Syllable With Trailing Jamo", i: "각각", want: []string{"각", "각"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := graphemes(tt.i)
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("graphemes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
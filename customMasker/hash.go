@@ -0,0 +1,137 @@
+package customMasker
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// HashAlgorithm is a hash function of hash mask types
+type HashAlgorithm int
+
+const (
+	// HashSHA256 hashes with SHA-256
+	HashSHA256 HashAlgorithm = iota
+	// HashBLAKE2b hashes with BLAKE2b-256
+	HashBLAKE2b
+)
+
+// HashEncoding is the encoding of the digests of hash mask types
+type HashEncoding int
+
+const (
+	// HashHex encodes digests in lowercase hexadecimal
+	HashHex HashEncoding = iota
+	// HashBase32 encodes digests in unpadded base32, RFC 4648
+	HashBase32
+	// HashBase64URL encodes digests in unpadded base64url, RFC 4648
+	HashBase64URL
+)
+
+// HashPolicy configures hashing. Values are replaced with the digest of the salt followed by the value, which can't be
+// reversed, with or without the salt. Unlike pseudonyms, the salt isn't a key: anyone knowing it can check whether a
+// digest belongs to a guessed value, so keep it secret for guessable values like phone numbers. Hashing needs a salt:
+// unsalted digests of emails or phone numbers could be reversed with a dictionary.
+type HashPolicy struct {
+	// Algorithm is the hash function, ignored by MHashSHA256 and MHashBLAKE2b which select their own
+	Algorithm HashAlgorithm
+	// Salt is prepended to values before hashing, so digests don't match those of other datasets. Required
+	Salt []byte
+	// Length truncates the encoded digests to a number of characters, all of them by default
+	Length int
+	// Encoding is the encoding of digests, hexadecimal by default
+	Encoding HashEncoding
+	// Prefix is prepended to digests, e.g. "sha256:"
+	Prefix string
+}
+
+// UpdateHashPolicy updates the policy used by MHashSHA256 and MHashBLAKE2b to hash values
+func (m *Masker) UpdateHashPolicy(policy HashPolicy) {
+	policy.Salt = append([]byte(nil), policy.Salt...)
+	m.hash = policy
+}
+
+// Hash replaces a value with its salted digest with the hash policy of the masker, by a hash function of MHashSHA256
+// or MHashBLAKE2b. Value and regex filters hash each match on its own. Values are masked entirely if the hash policy
+// has no salt.
+//
+// Example:
+//
+//	input: dummy@dummy.com
+//	output: 4dfe78f423f7fd15...
+func (m *Masker) Hash(t Mtype, i string) string {
+	policy := m.hash
+	switch t {
+	case MHashSHA256:
+		policy.Algorithm = HashSHA256
+	case MHashBLAKE2b:
+		policy.Algorithm = HashBLAKE2b
+	default:
+		return m.MaskWithSpec(MaskSpec{}, i)
+	}
+	if len(policy.Salt) == 0 {
+		return m.MaskWithSpec(MaskSpec{}, i)
+	}
+	return policy.digest(i)
+}
+
+// RegisterHash registers a user-defined mask type hashing values with its own policy. It returns ErrInvalidMaskType if
+// the policy has no salt.
+//
+// Example:
+//
+//	masker.RegisterHash(customMasker.Mtype("email_hash"), customMasker.HashPolicy{
+//		Algorithm: customMasker.HashBLAKE2b,
+//		Salt:      salt,
+//		Length:    16,
+//		Encoding:  customMasker.HashBase32,
+//	})
+func (m *Masker) RegisterHash(t Mtype, policy HashPolicy) error {
+	if policy.Algorithm != HashSHA256 && policy.Algorithm != HashBLAKE2b {
+		return fmt.Errorf("%w: unknown hash algorithm %d", ErrInvalidMaskType, policy.Algorithm)
+	}
+	if len(policy.Salt) == 0 {
+		return fmt.Errorf("%w: hash policy of %q without salt", ErrInvalidMaskType, t)
+	}
+	policy.Salt = append([]byte(nil), policy.Salt...)
+	if err := m.RegisterMaskType(t, policy.digest); err != nil {
+		return err
+	}
+	m.registry.registerMatchType(t)
+	return nil
+}
+
+func (p HashPolicy) digest(i string) string {
+	if i == "" {
+		return ""
+	}
+	var h hash.Hash
+	if p.Algorithm == HashBLAKE2b {
+		// New256 only fails for keys longer than 64 bytes
+		h, _ = blake2b.New256(nil)
+	} else {
+		h = sha256.New()
+	}
+	h.Write(p.Salt)
+	h.Write([]byte(i))
+	sum := h.Sum(nil)
+
+	var encoded string
+	switch p.Encoding {
+	case HashBase32:
+		encoded = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(sum)
+	case HashBase64URL:
+		encoded = base64.RawURLEncoding.EncodeToString(sum)
+	default:
+		encoded = hex.EncodeToString(sum)
+	}
+	if p.Length > 0 && p.Length < len(encoded) {
+		encoded = encoded[:p.Length]
+	}
+	return p.Prefix + encoded
+}
@@ -0,0 +1,55 @@
+package customMasker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMasker_Hash(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy HashPolicy
+		t      Mtype
+		input  string
+		want   string
+	}{
+		{name: "SHA-256", policy: HashPolicy{Salt: []byte("salt")}, t: MHashSHA256, input: "abc", want: "3681099918be28c95b81e27e7e5c2e4c6a6dea566d2d10e7f49139ebb779eb6f"},
+		{name: "BLAKE2b", policy: HashPolicy{Salt: []byte("salt")}, t: MHashBLAKE2b, input: "abc", want: "73dcb90e42e7b144ca856bdd4e0f9557c09960209c355dc81db4a2b5ab4a531d"},
+		{name: "Salt And Length", policy: HashPolicy{Salt: []byte("salt"), Length: 16}, t: MHashSHA256, input: "abc", want: "3681099918be28c9"},
+		{name: "Base32", policy: HashPolicy{Salt: []byte("salt"), Encoding: HashBase32}, t: MHashBLAKE2b, input: "abc", want: "OPOLSDSC46YUJSUFNPOU4D4VK7AJSYBATQ2V3SA5WSRLLK2KKMOQ"},
+		{name: "Base64URL", policy: HashPolicy{Salt: []byte("salt"), Encoding: HashBase64URL}, t: MHashSHA256, input: "abc", want: "NoEJmRi-KMlbgeJ-flwuTGpt6lZtLRDn9JE567d5628"},
+		{name: "Prefix", policy: HashPolicy{Salt: []byte("salt"), Length: 8, Prefix: "sha256:"}, t: MHashSHA256, input: "abc", want: "sha256:36810999"},
+		{name: "Algorithm Of Mask Type", policy: HashPolicy{Salt: []byte("salt"), Algorithm: HashSHA256, Length: 8}, t: MHashBLAKE2b, input: "abc", want: "73dcb90e"},
+		{name: "Length Beyond Digest", policy: HashPolicy{Salt: []byte("salt"), Length: 100, Encoding: HashBase64URL}, t: MHashSHA256, input: "abc", want: "NoEJmRi-KMlbgeJ-flwuTGpt6lZtLRDn9JE567d5628"},
+		{name: "Without Salt", policy: HashPolicy{Length: 8}, t: MHashSHA256, input: "abc", want: "***"},
+		{name: "Empty", policy: HashPolicy{Salt: []byte("salt")}, t: MHashSHA256, input: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMasker()
+			m.UpdateHashPolicy(tt.policy)
+			if got := m.String(tt.t, tt.input, ""); got != tt.want {
+				t.Errorf("Masker.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMasker_RegisterHash(t *testing.T) {
+	m := NewMasker()
+	if err := m.RegisterHash(Mtype("phone_hash"), HashPolicy{Salt: []byte("salt"), Length: 12}); err != nil {
+		t.Fatalf("Masker.RegisterHash() error = %v", err)
+	}
+	if got, want := m.String(Mtype("phone_hash"), "0912345678", ""), "a7ba89a49efe"; got != want {
+		t.Errorf("Masker.String() = %v, want %v", got, want)
+	}
+	if err := m.RegisterHash(Mtype("md5"), HashPolicy{Algorithm: HashAlgorithm(7), Salt: []byte("salt")}); !errors.Is(err, ErrInvalidMaskType) {
+		t.Errorf("Masker.RegisterHash() error = %v, want ErrInvalidMaskType", err)
+	}
+	if err := m.RegisterHash(Mtype("unsalted"), HashPolicy{}); !errors.Is(err, ErrInvalidMaskType) {
+		t.Errorf("Masker.RegisterHash() error = %v, want ErrInvalidMaskType without salt", err)
+	}
+	if err := m.RegisterHash(MHashSHA256, HashPolicy{Salt: []byte("salt")}); !errors.Is(err, ErrDuplicateMaskType) {
+		t.Errorf("Masker.RegisterHash() error = %v, want ErrDuplicateMaskType", err)
+	}
+}
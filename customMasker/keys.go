@@ -0,0 +1,270 @@
+package customMasker
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// ErrNoActiveKey is returned by key providers which have no key usable for new values yet
+var ErrNoActiveKey = errors.New("no active key")
+
+// KeyProvider provides the keys of keyed mask types: encryption, pseudonyms and tokens. New values are masked with
+// the active key, whose ID is carried by encrypted values and pseudonyms, and older keys are looked up by ID to
+// decrypt and verify the values masked before a rotation. Mask types never use these keys directly, see deriveKey.
+type KeyProvider interface {
+	// ActiveKey returns the key masking new values and its ID
+	ActiveKey() (id string, key []byte, err error)
+	// Key returns the key of an ID, or an error wrapping ErrUnknownKeyID
+	Key(id string) ([]byte, error)
+}
+
+// Purposes of the subkeys derived from the keys of a masker
+const (
+	keyPurposeEncrypt   = "encrypt"
+	keyPurposeToken     = "token"
+	keyPurposePseudonym = "pseudonym"
+	keyPurposeSecret    = "secret"
+	keyPurposeEmail     = "email"
+)
+
+// deriveKey derives the subkey of a purpose from a key with HKDF-SHA256. Every keyed mask type uses its own subkey, so
+// a key shared by encryption, tokens, pseudonyms, secret fingerprints and email hashes is never used by two
+// cryptographic primitives. Subkeys have the length of the key, but at least 32 bytes for HMAC purposes.
+func deriveKey(key []byte, purpose string) []byte {
+	size := len(key)
+	if purpose != keyPurposeEncrypt && purpose != keyPurposeToken && size < sha256.Size {
+		size = sha256.Size
+	}
+	subkey := make([]byte, size)
+	// HKDF-SHA256 only fails past 8160 bytes of output
+	if _, err := io.ReadFull(hkdf.New(sha256.New, key, nil, []byte("golang-masking-tool/"+purpose)), subkey); err != nil {
+		panic(err)
+	}
+	return subkey
+}
+
+// Keyring is a KeyProvider holding keys in memory. Keys are scheduled: the active key is the key whose activation
+// time passed most recently, and keys added later win ties. It is safe for concurrent use.
+type Keyring struct {
+	mu   sync.RWMutex
+	keys map[string]keyringEntry
+	seq  int
+	now  func() time.Time
+}
+
+type keyringEntry struct {
+	key       []byte
+	notBefore time.Time
+	seq       int
+}
+
+var _ KeyProvider = (*Keyring)(nil)
+
+// NewKeyring returns an empty keyring
+func NewKeyring() *Keyring {
+	return &Keyring{keys: map[string]keyringEntry{}, now: time.Now}
+}
+
+// AddKey adds a key which becomes active at notBefore, or replaces the key of the ID. Pass the zero time, or the
+// current time, to activate the key immediately. Key IDs may contain letters, digits, ".", "_" and "-".
+func (k *Keyring) AddKey(id string, key []byte, notBefore time.Time) error {
+	if !encryptionKeyIDRegex.MatchString(id) {
+		return fmt.Errorf("%w: key id %q", ErrInvalidEncryptionKey, id)
+	}
+	if len(key) == 0 {
+		return fmt.Errorf("%w: empty key %q", ErrInvalidEncryptionKey, id)
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.seq++
+	k.keys[id] = keyringEntry{key: append([]byte(nil), key...), notBefore: notBefore, seq: k.seq}
+	return nil
+}
+
+// ActiveKey returns the key whose activation time passed most recently
+func (k *Keyring) ActiveKey() (string, []byte, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	now := k.now()
+	activeID := ""
+	var active keyringEntry
+	for id, entry := range k.keys {
+		if entry.notBefore.After(now) {
+			continue
+		}
+		if activeID == "" || entry.notBefore.After(active.notBefore) ||
+			(entry.notBefore.Equal(active.notBefore) && entry.seq > active.seq) {
+			activeID, active = id, entry
+		}
+	}
+	if activeID == "" {
+		return "", nil, ErrNoActiveKey
+	}
+	return activeID, active.key, nil
+}
+
+// Key returns the key of an ID, including keys which aren't active yet or anymore
+func (k *Keyring) Key(id string) ([]byte, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	entry, ok := k.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, id)
+	}
+	return entry.key, nil
+}
+
+// IDs returns the IDs of the keys, sorted by activation time
+func (k *Keyring) IDs() []string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(a, b int) bool {
+		ea, eb := k.keys[ids[a]], k.keys[ids[b]]
+		if !ea.notBefore.Equal(eb.notBefore) {
+			return ea.notBefore.Before(eb.notBefore)
+		}
+		return ea.seq < eb.seq
+	})
+	return ids
+}
+
+// FileKeyring is a KeyProvider reading its keys from a JSON file:
+//
+//	{"keys": [
+//		{"id": "2024-01", "key": "<base64 key>", "not_before": "2024-01-01T00:00:00Z"},
+//		{"id": "2024-07", "key": "<base64 key>", "not_before": "2024-07-01T00:00:00Z"}
+//	]}
+//
+// Schedule rotations by adding keys activated in the future, and keep retired keys in the file as long as values
+// masked with them must be decrypted or verified. It is safe for concurrent use.
+type FileKeyring struct {
+	path string
+	mu   sync.Mutex
+	keys *Keyring
+}
+
+var _ KeyProvider = (*FileKeyring)(nil)
+
+type keyringFile struct {
+	Keys []keyringFileKey `json:"keys"`
+}
+
+type keyringFileKey struct {
+	ID        string    `json:"id"`
+	Key       string    `json:"key"`
+	NotBefore time.Time `json:"not_before,omitempty"`
+}
+
+// NewFileKeyring reads the keyring file at path. The file doesn't have to exist yet if keys are added with Rotate.
+func NewFileKeyring(path string) (*FileKeyring, error) {
+	k := &FileKeyring{path: path, keys: NewKeyring()}
+	if err := k.Reload(); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Reload reads the keyring file again, e.g. after another process rotated keys. The keys are kept if the file is
+// invalid.
+func (k *FileKeyring) Reload() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	data, err := ioutil.ReadFile(k.path)
+	if err != nil {
+		return err
+	}
+	var file keyringFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("keyring file %s: %w", k.path, err)
+	}
+	keys := NewKeyring()
+	keys.now = k.keys.now
+	for _, fileKey := range file.Keys {
+		key, err := base64.StdEncoding.DecodeString(fileKey.Key)
+		if err != nil {
+			return fmt.Errorf("keyring file %s: key %q: %w", k.path, fileKey.ID, err)
+		}
+		if err := keys.AddKey(fileKey.ID, key, fileKey.NotBefore); err != nil {
+			return fmt.Errorf("keyring file %s: %w", k.path, err)
+		}
+	}
+	k.keys = keys
+	return nil
+}
+
+// Rotate adds a key activated at notBefore and saves the keyring file. Previous keys stay in the file.
+func (k *FileKeyring) Rotate(id string, key []byte, notBefore time.Time) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	keys := NewKeyring()
+	keys.now = k.keys.now
+	var file keyringFile
+	for _, keyID := range k.keys.IDs() {
+		if keyID == id {
+			continue
+		}
+		entry := k.keys.keys[keyID]
+		file.Keys = append(file.Keys, keyringFileKey{ID: keyID, Key: base64.StdEncoding.EncodeToString(entry.key), NotBefore: entry.notBefore})
+		keys.keys[keyID] = entry
+	}
+	keys.seq = k.keys.seq
+	if err := keys.AddKey(id, key, notBefore); err != nil {
+		return err
+	}
+	file.Keys = append(file.Keys, keyringFileKey{ID: id, Key: base64.StdEncoding.EncodeToString(key), NotBefore: notBefore})
+
+	data, err := json.MarshalIndent(file, "", "\t")
+	if err != nil {
+		return err
+	}
+	// write a temporary file renamed over the keyring, so readers never see a partial file
+	tmp, err := ioutil.TempFile(filepath.Dir(k.path), filepath.Base(k.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), k.path); err != nil {
+		return err
+	}
+	k.keys = keys
+	return nil
+}
+
+// ActiveKey returns the key whose activation time passed most recently
+func (k *FileKeyring) ActiveKey() (string, []byte, error) {
+	return k.current().ActiveKey()
+}
+
+// Key returns the key of an ID
+func (k *FileKeyring) Key(id string) ([]byte, error) {
+	return k.current().Key(id)
+}
+
+func (k *FileKeyring) current() *Keyring {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.keys
+}
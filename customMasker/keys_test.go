@@ -0,0 +1,211 @@
+package customMasker
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	testRotationStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	testRotatedKey    = []byte("fedcba9876543210fedcba9876543210")
+)
+
+func TestKeyring_ActiveKey(t *testing.T) {
+	k := NewKeyring()
+	now := testRotationStart
+	k.now = func() time.Time { return now }
+	if _, _, err := k.ActiveKey(); !errors.Is(err, ErrNoActiveKey) {
+		t.Errorf("Keyring.ActiveKey() error = %v, want ErrNoActiveKey", err)
+	}
+
+	mustAddKey(t, k, "2024-01", testEncryptionKey, testRotationStart)
+	mustAddKey(t, k, "2024-07", testRotatedKey, testRotationStart.AddDate(0, 6, 0))
+	if id, _, err := k.ActiveKey(); err != nil || id != "2024-01" {
+		t.Errorf("Keyring.ActiveKey() = %v, %v, want 2024-01 before the rotation", id, err)
+	}
+	now = testRotationStart.AddDate(0, 6, 0)
+	if id, key, err := k.ActiveKey(); err != nil || id != "2024-07" || !reflect.DeepEqual(key, testRotatedKey) {
+		t.Errorf("Keyring.ActiveKey() = %v, %v, want 2024-07 after the rotation", id, err)
+	}
+	if key, err := k.Key("2024-01"); err != nil || !reflect.DeepEqual(key, testEncryptionKey) {
+		t.Errorf("Keyring.Key() = %v, %v, want the retired key", key, err)
+	}
+	if _, err := k.Key("2023-01"); !errors.Is(err, ErrUnknownKeyID) {
+		t.Errorf("Keyring.Key() error = %v, want ErrUnknownKeyID", err)
+	}
+
+	mustAddKey(t, k, "emergency", testEncryptionKey, now)
+	if id, _, _ := k.ActiveKey(); id != "emergency" {
+		t.Errorf("Keyring.ActiveKey() = %v, want the key added last to win ties", id)
+	}
+	if got, want := k.IDs(), []string{"2024-01", "2024-07", "emergency"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keyring.IDs() = %v, want %v", got, want)
+	}
+	if err := k.AddKey("a:b", testEncryptionKey, now); !errors.Is(err, ErrInvalidEncryptionKey) {
+		t.Errorf("Keyring.AddKey() error = %v, want ErrInvalidEncryptionKey", err)
+	}
+	if err := k.AddKey("empty", nil, now); !errors.Is(err, ErrInvalidEncryptionKey) {
+		t.Errorf("Keyring.AddKey() error = %v, want ErrInvalidEncryptionKey", err)
+	}
+}
+
+func TestFileKeyring(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keyring")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "keys.json")
+	data := `{"keys": [{"id": "2024-01", "key": "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=", "not_before": "2024-01-01T00:00:00Z"}]}`
+	if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	k, err := NewFileKeyring(path)
+	if err != nil {
+		t.Fatalf("NewFileKeyring() error = %v", err)
+	}
+	if id, key, err := k.ActiveKey(); err != nil || id != "2024-01" || !reflect.DeepEqual(key, testEncryptionKey) {
+		t.Errorf("FileKeyring.ActiveKey() = %v, %v, %v, want 2024-01", id, key, err)
+	}
+	if err := k.Rotate("2024-07", testRotatedKey, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("FileKeyring.Rotate() error = %v", err)
+	}
+	if id, _, _ := k.ActiveKey(); id != "2024-07" {
+		t.Errorf("FileKeyring.ActiveKey() = %v, want the rotated key", id)
+	}
+
+	reloaded, err := NewFileKeyring(path)
+	if err != nil {
+		t.Fatalf("NewFileKeyring() error = %v", err)
+	}
+	if id, _, _ := reloaded.ActiveKey(); id != "2024-07" {
+		t.Errorf("FileKeyring.ActiveKey() = %v, want the rotated key saved", id)
+	}
+	if key, err := reloaded.Key("2024-01"); err != nil || !reflect.DeepEqual(key, testEncryptionKey) {
+		t.Errorf("FileKeyring.Key() = %v, %v, want the retired key saved", key, err)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := reloaded.Reload(); err == nil {
+		t.Errorf("FileKeyring.Reload() error = nil, want an error for an invalid file")
+	}
+	if id, _, _ := reloaded.ActiveKey(); id != "2024-07" {
+		t.Errorf("FileKeyring.ActiveKey() = %v, want the keys kept after an invalid reload", id)
+	}
+
+	missing, err := NewFileKeyring(filepath.Join(dir, "missing.json"))
+	if err != nil {
+		t.Fatalf("NewFileKeyring() error = %v, want missing files to be created by Rotate", err)
+	}
+	if _, _, err := missing.ActiveKey(); !errors.Is(err, ErrNoActiveKey) {
+		t.Errorf("FileKeyring.ActiveKey() error = %v, want ErrNoActiveKey", err)
+	}
+}
+
+func TestMasker_KeyRotation(t *testing.T) {
+	keys := NewKeyring()
+	now := testRotationStart
+	keys.now = func() time.Time { return now }
+	mustAddKey(t, keys, "2024-01", testEncryptionKey, testRotationStart)
+	mustAddKey(t, keys, "2024-07", testRotatedKey, testRotationStart.AddDate(0, 6, 0))
+
+	m := NewMasker()
+	m.UpdatePseudonymPolicy(PseudonymPolicy{Prefix: "usr_"})
+	if err := m.UseKeyProvider(keys); err != nil {
+		t.Fatalf("Masker.UseKeyProvider() error = %v", err)
+	}
+	encrypted := m.Encrypt("dummy@dummy.com")
+	pseudonym := m.Pseudonym("dummy@dummy.com")
+	token := m.TokenizeDigits("0912345678")
+	if !strings.HasPrefix(encrypted, "enc:v1:2024-01:") || !strings.HasPrefix(pseudonym, "usr_2024-01:") {
+		t.Fatalf("Masker.Encrypt() = %v, Masker.Pseudonym() = %v, want key id 2024-01", encrypted, pseudonym)
+	}
+	if m.TokenKeyID() != "2024-01" {
+		t.Errorf("Masker.TokenKeyID() = %v, want 2024-01", m.TokenKeyID())
+	}
+
+	now = testRotationStart.AddDate(0, 6, 0)
+	if got := m.Encrypt("dummy@dummy.com"); !strings.HasPrefix(got, "enc:v1:2024-07:") {
+		t.Errorf("Masker.Encrypt() = %v, want key id 2024-07 after the rotation", got)
+	}
+	if got := m.Pseudonym("dummy@dummy.com"); !strings.HasPrefix(got, "usr_2024-07:") || got == pseudonym {
+		t.Errorf("Masker.Pseudonym() = %v, want key id 2024-07 after the rotation", got)
+	}
+	if got, err := m.Decrypt(encrypted); err != nil || got != "dummy@dummy.com" {
+		t.Errorf("Masker.Decrypt() = %v, %v, want values encrypted before the rotation to decrypt", got, err)
+	}
+	if ok, err := m.VerifyPseudonym("dummy@dummy.com", pseudonym); err != nil || !ok {
+		t.Errorf("Masker.VerifyPseudonym() = %v, %v, want pseudonyms of before the rotation to verify", ok, err)
+	}
+	if ok, _ := m.VerifyPseudonym("other@dummy.com", pseudonym); ok {
+		t.Errorf("Masker.VerifyPseudonym() = true, want false for another value")
+	}
+	if got, err := m.Detokenize(MTokenDigits, token); err != nil || got != "0912345678" {
+		t.Errorf("Masker.Detokenize() = %v, %v, want tokens pinned to their key", got, err)
+	}
+	if got := m.TokenizeDigits("0912345678"); got != token {
+		t.Errorf("Masker.TokenizeDigits() = %v, want %v until the token policy is updated", got, token)
+	}
+	if err := m.UpdateTokenPolicy(TokenPolicy{Keys: keys}); err != nil || m.TokenKeyID() != "2024-07" {
+		t.Errorf("Masker.UpdateTokenPolicy() error = %v, key id %v, want 2024-07", err, m.TokenKeyID())
+	}
+	if err := m.UpdateTokenPolicy(TokenPolicy{Keys: keys, KeyID: "2024-01"}); err != nil {
+		t.Fatalf("Masker.UpdateTokenPolicy() error = %v", err)
+	}
+	if got, err := m.Detokenize(MTokenDigits, token); err != nil || got != "0912345678" {
+		t.Errorf("Masker.Detokenize() = %v, %v, want tokens of KeyID 2024-01", got, err)
+	}
+
+	if _, err := NewMasker().VerifyPseudonym("dummy@dummy.com", "2024-01:"+pseudonym[len("usr_2024-01:"):]); !errors.Is(err, ErrUnknownKeyID) {
+		t.Errorf("Masker.VerifyPseudonym() error = %v, want ErrUnknownKeyID without key provider", err)
+	}
+}
+
+func mustAddKey(t *testing.T, k *Keyring, id string, key []byte, notBefore time.Time) {
+	t.Helper()
+	if err := k.AddKey(id, key, notBefore); err != nil {
+		t.Fatalf("Keyring.AddKey() error = %v", err)
+	}
+}
+
+func TestMasker_KeySeparation(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	subkeys := map[string]bool{}
+	for _, purpose := range []string{keyPurposeEncrypt, keyPurposeToken, keyPurposePseudonym, keyPurposeSecret, keyPurposeEmail} {
+		subkey := deriveKey(key, purpose)
+		if len(subkey) != len(key) || reflect.DeepEqual(subkey, key) || subkeys[string(subkey)] {
+			t.Errorf("deriveKey(%v) = %x, want a subkey of its own", purpose, subkey)
+		}
+		subkeys[string(subkey)] = true
+	}
+	if got := deriveKey([]byte("0123456789abcdef"), keyPurposeSecret); len(got) != 32 {
+		t.Errorf("deriveKey() = %d bytes, want 32 bytes for HMAC purposes", len(got))
+	}
+	if got := deriveKey([]byte("0123456789abcdef"), keyPurposeEncrypt); len(got) != 16 {
+		t.Errorf("deriveKey() = %d bytes, want the 16 bytes of the AES key", len(got))
+	}
+
+	keys := NewKeyring()
+	if err := keys.AddKey("2024-01", key, testRotationStart); err != nil {
+		t.Fatalf("Keyring.AddKey() error = %v", err)
+	}
+	m := NewMasker()
+	if err := m.UseKeyProvider(keys); err != nil {
+		t.Fatalf("Masker.UseKeyProvider() error = %v", err)
+	}
+	m.UpdateEmailPolicy(EmailPolicy{Local: EmailHashLocal})
+	pseudonym := strings.TrimPrefix(m.Pseudonym("dummy"), "2024-01:")
+	local := strings.TrimSuffix(m.Email("dummy@dummy.com"), "@dummy.com")
+	if strings.HasPrefix(pseudonym, local) {
+		t.Errorf("Masker.Email() = %v, want a hash other than the pseudonym %v", local, pseudonym)
+	}
+}
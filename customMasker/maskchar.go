@@ -0,0 +1,89 @@
+package customMasker
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// maskChars are the masking character and mask style of a masker or a mask type
+type maskChars struct {
+	chars string
+	style MaskStyle
+}
+
+// UpdateTypeMaskingCharacter sets the masking character used by the built-in masking of a mask type and by the mask
+// types registered on the masker, overriding the masking character and mask style of the masker
+//
+// Example:
+//
+//	masker.UpdateTypeMaskingCharacter(customMasker.MCreditCard, customMasker.PBullet)
+//	masker.String(customMasker.MCreditCard, "4111-1111-1111-1111", "")
+//	// 4111-11••-••••-1111
+func (m *Masker) UpdateTypeMaskingCharacter(t Mtype, maskingCharacter MaskingCharacter) {
+	m.updateTypeMask(t, maskChars{chars: string(maskingCharacter)})
+}
+
+// UpdateMaskStyle sets how the masker replaces masked characters. SMaskingCharacter masks with the masking character
+// of the masker, which UpdateMaskingCharacter also restores.
+func (m *Masker) UpdateMaskStyle(style MaskStyle) {
+	m.style = style
+}
+
+// UpdateTypeMaskStyle sets how masked characters of a mask type are replaced, overriding the masking character and
+// mask style of the masker
+//
+// Example:
+//
+//	masker.UpdateTypeMaskStyle(customMasker.MCreditCard, customMasker.SClassPreserving)
+//	masker.String(customMasker.MCreditCard, "4111-1111-1111-1111", "")
+//	// 4111-11##-####-1111
+func (m *Masker) UpdateTypeMaskStyle(t Mtype, style MaskStyle) {
+	mask, ok := m.typeMasks[t]
+	if !ok {
+		mask = m.glyphs()
+	}
+	mask.style = style
+	m.updateTypeMask(t, mask)
+}
+
+func (m *Masker) updateTypeMask(t Mtype, mask maskChars) {
+	if m.typeMasks == nil {
+		m.typeMasks = map[Mtype]maskChars{}
+	}
+	m.typeMasks[t] = mask
+}
+
+// maskGlyph returns the masking character replacing the k-th masked character of a run. Any Unicode glyph can be a
+// masking character: masks of several characters, e.g. "[X]", are cycled through so the masked string keeps the length
+// of the original. SClassPreserving masks digits with "#" and other characters with "X".
+func maskGlyph(mask maskChars, cluster string, k int) string {
+	if mask.style == SClassPreserving {
+		if c, _ := utf8.DecodeRuneInString(cluster); unicode.IsDigit(c) {
+			return "#"
+		}
+		return "X"
+	}
+	if len(mask.chars) == 1 {
+		return mask.chars
+	}
+	glyphs := graphemes(mask.chars)
+	if len(glyphs) <= 1 {
+		return mask.chars
+	}
+	return glyphs[k%len(glyphs)]
+}
+
+// glyphs returns the masking character and mask style of the masker
+func (m *Masker) glyphs() maskChars {
+	return maskChars{chars: m.mask, style: m.style}
+}
+
+// maskClusters masks every character of clusters
+func (m *Masker) maskClusters(clusters []string) string {
+	mask := m.glyphs()
+	masked := make([]byte, 0, len(clusters)*len(m.mask))
+	for k, cluster := range clusters {
+		masked = append(masked, maskGlyph(mask, cluster, k)...)
+	}
+	return string(masked)
+}
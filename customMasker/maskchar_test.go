@@ -0,0 +1,89 @@
+package customMasker
+
+import "testing"
+
+func TestMasker_MaskingCharacter(t *testing.T) {
+	tests := []struct {
+		name  string
+		mask  MaskingCharacter
+		style MaskStyle
+		t     Mtype
+		i     string
+		want  string
+	}{
+		{name: "Bullet", mask: PBullet, t: MCreditCard, i: "4111-1111-1111-1111", want: "4111-11••-••••-1111"},
+		{name: "Emoji", mask: MaskingCharacter("🙈"), t: MMobile, i: "0978978978", want: "0978🙈🙈🙈978"},
+		{name: "Several Characters", mask: MaskingCharacter("[X]"), t: MCreditCard, i: "4111111111111111", want: "411111[X][X]1111"},
+		{name: "Class Preserving Card", style: SClassPreserving, t: MCreditCard, i: "4111-1111-1111-1111", want: "4111-11##-####-1111"},
+		{name: "Class Preserving ID", style: SClassPreserving, t: MID, i: "A1234567BC", want: "A12345##XX"},
+		{name: "Class Preserving Name", style: SClassPreserving, t: MName, i: "王小明", want: "王XX"},
+		{name: "Class Preserving Phone", style: SClassPreserving, t: MPhone, i: "+1 (415) 555-2671", want: "+1 (###) ###-2671"},
+		{name: "Class Preserving Password", style: SClassPreserving, t: MPassword, i: "secret", want: "XXXXXXXXXXXX"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMasker()
+			if tt.mask != "" {
+				m.UpdateMaskingCharacter(tt.mask)
+			}
+			m.UpdateMaskStyle(tt.style)
+			if got := m.String(tt.t, tt.i, ""); got != tt.want {
+				t.Errorf("Masker.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMasker_UpdateTypeMaskingCharacter(t *testing.T) {
+	m := NewMasker()
+	m.UpdateTypeMaskStyle(MCreditCard, SClassPreserving)
+	m.UpdateTypeMaskingCharacter(MMobile, PCross)
+
+	if got, want := m.String(MCreditCard, "4111-1111-1111-1111", ""), "4111-11##-####-1111"; got != want {
+		t.Errorf("Masker.String(MCreditCard) = %v, want %v", got, want)
+	}
+	if got, want := m.String(MMobile, "0978978978", ""), "0978xxx978"; got != want {
+		t.Errorf("Masker.String(MMobile) = %v, want %v", got, want)
+	}
+	if got, want := m.String(MID, "A123456789", ""), "A12345****"; got != want {
+		t.Errorf("Masker.String(MID) = %v, want %v", got, want)
+	}
+	if got, want := m.CreditCard("4111-1111-1111-1111"), "4111-11**-****-1111"; got != want {
+		t.Errorf("Masker.CreditCard() = %v, want %v", got, want)
+	}
+}
+
+func TestMasker_MaskingCharacterOfRegisteredTypes(t *testing.T) {
+	m := NewMasker()
+	if err := m.RegisterMaskSpec(Mtype("iban"), MaskSpec{KeepLast: 4}); err != nil {
+		t.Fatalf("Masker.RegisterMaskSpec() error = %v", err)
+	}
+	if err := m.RegisterPseudonym(Mtype("account"), "acct_"); err != nil {
+		t.Fatalf("Masker.RegisterPseudonym() error = %v", err)
+	}
+	m.UpdateTypeMaskingCharacter(Mtype("iban"), PHyphen)
+	m.UpdateTypeMaskStyle(Mtype("account"), SClassPreserving)
+
+	if got, want := m.String(Mtype("iban"), "DE8937040044", ""), "--------0044"; got != want {
+		t.Errorf("Masker.String(iban) = %v, want %v", got, want)
+	}
+	if got, want := m.String(Mtype("account"), "ab12", ""), "XX##"; got != want {
+		t.Errorf("Masker.String(account) = %v, want %v", got, want)
+	}
+	if got, want := m.MaskWithSpec(MaskSpec{KeepLast: 4}, "DE8937040044"), "********0044"; got != want {
+		t.Errorf("Masker.MaskWithSpec() = %v, want %v", got, want)
+	}
+}
+
+func TestMasker_MaskStyleIsNotAMaskingCharacter(t *testing.T) {
+	m := NewMasker()
+	m.UpdateMaskingCharacter(MaskingCharacter("\x00#X"))
+	if got, want := m.String(MPassword, "secret", ""), "\x00#X\x00#X\x00#X\x00#X"; got != want {
+		t.Errorf("Masker.String() = %q, want %q", got, want)
+	}
+	m.UpdateMaskStyle(SClassPreserving)
+	m.UpdateMaskingCharacter(PCross)
+	if got, want := m.String(MPassword, "secret", ""), "xxxxxxxxxxxx"; got != want {
+		t.Errorf("Masker.String() = %v, want %v after UpdateMaskingCharacter", got, want)
+	}
+}
@@ -198,6 +198,53 @@ func TestMasker_String(t *testing.T) {
 	}
 }
 
+// minimalMasker only implements MaskerInterface
+type minimalMasker struct{}
+
+func (minimalMasker) String(t Mtype, i string, defaultFilteredString string) string {
+	return MaskString(minimalMasker{}, t, i, defaultFilteredString)
+}
+
+func (minimalMasker) Name(i string) string                                     { return "name" }
+func (minimalMasker) ID(i string) string                                       { return "id" }
+func (minimalMasker) Address(i string) string                                  { return "address" }
+func (minimalMasker) CreditCard(i string) string                               { return "card" }
+func (minimalMasker) Email(i string) string                                    { return "email" }
+func (minimalMasker) Mobile(i string) string                                   { return "mobile" }
+func (minimalMasker) Telephone(i string) string                                { return "telephone" }
+func (minimalMasker) Password(i string) string                                 { return "password" }
+func (minimalMasker) URL(i string) string                                      { return "url" }
+func (minimalMasker) UpdateMaskingCharacter(maskingCharacter MaskingCharacter) {}
+
+// hashingMasker also implements HashMasker
+type hashingMasker struct {
+	minimalMasker
+}
+
+func (hashingMasker) Hash(t Mtype, i string) string { return "hash:" + string(t) }
+
+func TestMaskString_OptionalInterfaces(t *testing.T) {
+	tests := []struct {
+		name string
+		m    MaskerInterface
+		t    Mtype
+		want string
+	}{
+		{name: "Phone Falls Back To Mobile", m: minimalMasker{}, t: MPhone, want: "mobile"},
+		{name: "Missing Optional Interface", m: minimalMasker{}, t: MHashSHA256, want: "[filtered]"},
+		{name: "Missing DSN", m: minimalMasker{}, t: MDSN, want: "[filtered]"},
+		{name: "Optional Interface", m: hashingMasker{}, t: MHashBLAKE2b, want: "hash:blake2b"},
+		{name: "Embedded Masker", m: NewMasker(), t: MPhone, want: "+886 *** **5 678"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MaskString(tt.m, tt.t, "+886 912 345 678", "[filtered]"); got != tt.want {
+				t.Errorf("MaskString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestMasker_Name(t *testing.T) {
 	type args struct {
 		i string
@@ -272,6 +319,62 @@ func TestMasker_Name(t *testing.T) {
 			},
 			want: "J**ge M**ry",
 		},
+		{
+			name: "Hyphenated Name",
+			m:    NewMasker(),
+			args: args{
+				i: "Mary-Jane Watson",
+			},
+			want: "M**y-J**e W**son",
+		},
+		{
+			name: "Combining Marks",
+			m:    NewMasker(),
+			args: args{
+				i: "Jose\u0301 Zoe\u0308",
+			},
+			want: "J**e\u0301 Z**e\u0308",
+		},
+		{
+			name: "Chinese Name",
+			m:    NewMasker(),
+			args: args{
+				i: "王小明",
+			},
+			want: "王**",
+		},
+		{
+			name: "Chinese Compound Family Name",
+			m:    NewMasker(),
+			args: args{
+				i: "歐陽娜娜",
+			},
+			want: "歐陽**",
+		},
+		{
+			name: "Japanese Name With Space",
+			m:    NewMasker(),
+			args: args{
+				i: "田中 太郎",
+			},
+			want: "田中 **",
+		},
+		{
+			name: "Korean Name In Jamo",
+			m:    NewMasker(),
+			args: args{
+				i: "\u1100\u1175\u11B7\u1106\u1175\u11AB\u110C\u116E\u11AB",
+			},
+			want: "\u1100\u1175\u11B7**",
+		},
+		{
+			name: "Transliterated Name",
+			m:    NewMasker(),
+			args: args{
+				i: "約翰·史密斯",
+			},
+			want: "約*·史**",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -308,6 +411,22 @@ func TestMasker_ID(t *testing.T) {
 			},
 			want: "A12345****",
 		},
+		{
+			name: "Lower Case Taiwan ID",
+			m:    NewMasker(),
+			args: args{
+				i: "a123456789",
+			},
+			want: "A12345****",
+		},
+		{
+			name: "Lower Case Other ID",
+			m:    NewMasker(),
+			args: args{
+				i: "a123456788",
+			},
+			want: "a12345****",
+		},
 		{
 			name: "Length Less Than 6",
 			m:    NewMasker(),
@@ -418,7 +537,55 @@ func TestMasker_CreditCard(t *testing.T) {
 			args: args{
 				i: "123456789012345",
 			},
-			want: "123456******345",
+			want: "123456*****2345",
+		},
+		{
+			name: "Separators",
+			m:    NewMasker(),
+			args: args{
+				i: "4444-4444-4444-4444",
+			},
+			want: "4444-44**-****-4444",
+		},
+		{
+			name: "19 Digits",
+			m:    NewMasker(),
+			args: args{
+				i: "6304 0000 0000 0000 018",
+			},
+			want: "6304 00** **** ***0 018",
+		},
+		{
+			name: "Last 4 Only",
+			m:    &Masker{mask: "*", card: CardPolicy{Rule: CardLast4}},
+			args: args{
+				i: "4444 4444 4444 4444",
+			},
+			want: "**** **** **** 4444",
+		},
+		{
+			name: "Too Few Digits",
+			m:    NewMasker(),
+			args: args{
+				i: "1234-5678",
+			},
+			want: "****-****",
+		},
+		{
+			name: "Valid Luhn",
+			m:    &Masker{mask: "*", card: CardPolicy{ValidateLuhn: true}},
+			args: args{
+				i: "4111-1111-1111-1111",
+			},
+			want: "4111-11**-****-1111",
+		},
+		{
+			name: "Invalid Luhn",
+			m:    &Masker{mask: "*", card: CardPolicy{ValidateLuhn: true}},
+			args: args{
+				i: "4111-1111-1111-1112",
+			},
+			want: "****-****-****-****",
 		},
 	}
 	for _, tt := range tests {
@@ -430,6 +597,26 @@ func TestMasker_CreditCard(t *testing.T) {
 	}
 }
 
+func TestLuhnValid(t *testing.T) {
+	tests := []struct {
+		name string
+		i    string
+		want bool
+	}{
+		{name: "Empty Input", i: "", want: false},
+		{name: "VISA", i: "4111111111111111", want: true},
+		{name: "American Express With Separators", i: "3782 822463 10005", want: true},
+		{name: "Invalid Check Digit", i: "4111111111111112", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LuhnValid(tt.i); got != tt.want {
+				t.Errorf("LuhnValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestMasker_Email(t *testing.T) {
 	type args struct {
 		i string
@@ -516,6 +703,22 @@ func TestMasker_Mobile(t *testing.T) {
 			},
 			want: "0912***678",
 		},
+		{
+			name: "International Format",
+			m:    NewMasker(),
+			args: args{
+				i: "+886 987 654 321",
+			},
+			want: "+886 *** **4 321",
+		},
+		{
+			name: "International Prefix",
+			m:    NewMasker(),
+			args: args{
+				i: "0044 20 7946 0958",
+			},
+			want: "0044 ** **** 0958",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -574,7 +777,15 @@ func TestMasker_Telephone(t *testing.T) {
 			args: args{
 				i: "2349966",
 			},
-			want: "2349966",
+			want: "*******",
+		},
+		{
+			name: "International",
+			m:    NewMasker(),
+			args: args{
+				i: "+886 2 2799 3078",
+			},
+			want: "+886 * **** 3078",
 		},
 	}
 	for _, tt := range tests {
@@ -637,7 +848,7 @@ func TestNew(t *testing.T) {
 	}{
 		{
 			name: "New Instance",
-			want: &Masker{mask: "*"},
+			want: &Masker{mask: "*", registry: &maskRegistry{}, phone: defaultPhonePolicy, url: DefaultURLPolicy},
 		},
 	}
 	for _, tt := range tests {
@@ -938,7 +1149,7 @@ func TestCreditCard(t *testing.T) {
 			args: args{
 				i: "123456789012345",
 			},
-			want: "123456******345",
+			want: "123456*****2345",
 		},
 	}
 	for _, tt := range tests {
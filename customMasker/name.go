@@ -0,0 +1,80 @@
+package customMasker
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Compound family names, which are kept as a whole
+var compoundFamilyNames = []string{
+	// Chinese
+	"歐陽", "欧阳", "司馬", "司马", "上官", "諸葛", "诸葛", "東方", "东方", "皇甫", "尉遲", "尉迟", "公孫", "公孙",
+	"慕容", "長孫", "长孙", "夏侯", "軒轅", "轩辕", "令狐", "宇文", "司徒", "張簡", "范姜",
+	// Korean
+	"남궁", "황보", "제갈", "선우", "독고", "사공", "서문",
+}
+
+// isNameSeparator reports whether a character separates parts of a Western name
+func isNameSeparator(cluster string) bool {
+	c, _ := utf8.DecodeRuneInString(cluster)
+	return unicode.IsSpace(c) || unicode.Is(unicode.Pd, c)
+}
+
+// isCJKName reports whether all letters of the name are Chinese, Japanese or Korean
+func isCJKName(i string) bool {
+	letters := 0
+	for _, c := range i {
+		if !unicode.IsLetter(c) {
+			continue
+		}
+		if !unicode.In(c, unicode.Han, unicode.Hangul, unicode.Hiragana, unicode.Katakana) {
+			return false
+		}
+		letters++
+	}
+	return letters > 0
+}
+
+// cjkName keeps the family name and masks every character of the given name. Transliterated names whose parts are
+// separated by a middle dot are written given name first, so each part keeps its first character instead.
+func (m *Masker) cjkName(i string) string {
+	if strings.ContainsAny(i, "·・") {
+		var b strings.Builder
+		part := 0
+		for _, cluster := range graphemes(i) {
+			switch {
+			case cluster == "·" || cluster == "・":
+				part = 0
+			case part > 0:
+				cluster = maskGlyph(m.glyphs(), cluster, part-1)
+				part++
+			default:
+				part++
+			}
+			b.WriteString(cluster)
+		}
+		return b.String()
+	}
+
+	if fields := strings.Fields(i); len(fields) > 1 {
+		masked := []string{fields[0]}
+		for _, field := range fields[1:] {
+			masked = append(masked, m.maskClusters(graphemes(field)))
+		}
+		return strings.Join(masked, " ")
+	}
+
+	clusters := graphemes(i)
+	if len(clusters) == 1 {
+		return strLoop(m.glyphs(), len("**"))
+	}
+	family := 1
+	for _, compound := range compoundFamilyNames {
+		if strings.HasPrefix(i, compound) && len(clusters) > graphemeLen(compound) {
+			family = graphemeLen(compound)
+			break
+		}
+	}
+	return strings.Join(clusters[:family], "") + m.maskClusters(clusters[family:])
+}
@@ -0,0 +1,160 @@
+package customMasker
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidNoise is returned by RegisterGeneralizer for noise generalizers with invalid parameters, like a
+// non-positive epsilon, which would turn values into NaN or infinities
+var ErrInvalidNoise = errors.New("invalid noise parameters")
+
+// LaplaceNoise perturbs numbers with Laplace noise of scale sensitivity/epsilon, the Laplace mechanism of
+// differential privacy. sensitivity is the most a single person can change the value, and smaller epsilons add more
+// noise. Pass a seeded source, like rand.NewSource(1), for reproducible noise, or nil for a source seeded randomly.
+// sensitivity must not be negative and epsilon must be positive, or RegisterGeneralizer returns ErrInvalidNoise.
+//
+// Example:
+//
+//	masker.RegisterGeneralizer(customMasker.Mtype("visits"), customMasker.LaplaceNoise(1, 0.5, nil))
+func LaplaceNoise(sensitivity float64, epsilon float64, source rand.Source) Generalizer {
+	if err := validateNoise(sensitivity, epsilon); err != nil {
+		return perturber{err: err}
+	}
+	scale := sensitivity / epsilon
+	return newPerturber(source, func(rng *rand.Rand, v float64) float64 {
+		u := rng.Float64() - 0.5
+		for u == -0.5 {
+			u = rng.Float64() - 0.5
+		}
+		if u < 0 {
+			return v + scale*math.Log(1+2*u)
+		}
+		return v - scale*math.Log(1-2*u)
+	})
+}
+
+// GaussianNoise perturbs numbers with Gaussian noise calibrated for (epsilon, delta)-differential privacy, with a
+// standard deviation of sensitivity*sqrt(2*ln(1.25/delta))/epsilon. Pass a seeded source for reproducible noise, or
+// nil for a source seeded randomly. delta must be between 0 and 1, exclusive.
+func GaussianNoise(sensitivity float64, epsilon float64, delta float64, source rand.Source) Generalizer {
+	if err := validateNoise(sensitivity, epsilon); err != nil {
+		return perturber{err: err}
+	}
+	if !(delta > 0 && delta < 1) {
+		return perturber{err: fmt.Errorf("%w: delta %v isn't between 0 and 1", ErrInvalidNoise, delta)}
+	}
+	sigma := sensitivity * math.Sqrt(2*math.Log(1.25/delta)) / epsilon
+	return newPerturber(source, func(rng *rand.Rand, v float64) float64 {
+		return v + rng.NormFloat64()*sigma
+	})
+}
+
+// Jitter multiplies numbers by a random factor between 1-fraction and 1+fraction, e.g. 0.05 for at most 5% of
+// change. Pass a seeded source for reproducible jitter, or nil for a source seeded randomly. fraction must be between 0
+// and 1.
+func Jitter(fraction float64, source rand.Source) Generalizer {
+	if !(fraction >= 0 && fraction <= 1) {
+		return perturber{err: fmt.Errorf("%w: jitter fraction %v isn't between 0 and 1", ErrInvalidNoise, fraction)}
+	}
+	return newPerturber(source, func(rng *rand.Rand, v float64) float64 {
+		return v * (1 + fraction*(2*rng.Float64()-1))
+	})
+}
+
+// SignificantFigures rounds numbers to a number of significant figures, e.g. 52345 to 52000 with 2 figures
+func SignificantFigures(figures int) Generalizer {
+	return perturber{perturb: func(v float64) float64 {
+		if v == 0 || figures <= 0 {
+			return v
+		}
+		scale := math.Pow(10, float64(figures)-math.Ceil(math.Log10(math.Abs(v))))
+		return math.Round(v*scale) / scale
+	}}
+}
+
+// validateNoise checks the parameters shared by the Laplace and Gaussian mechanisms
+func validateNoise(sensitivity float64, epsilon float64) error {
+	if !(sensitivity >= 0) || math.IsInf(sensitivity, 0) {
+		return fmt.Errorf("%w: sensitivity %v isn't a non-negative number", ErrInvalidNoise, sensitivity)
+	}
+	if !(epsilon > 0) || math.IsInf(epsilon, 0) {
+		return fmt.Errorf("%w: epsilon %v isn't a positive number", ErrInvalidNoise, epsilon)
+	}
+	return nil
+}
+
+// perturber changes numbers, of strings or typed values. Integers are rounded to the nearest integer, and clamped to
+// the range of their type. Perturbers with invalid parameters keep err and don't change any value.
+type perturber struct {
+	perturb func(v float64) float64
+	err     error
+}
+
+// validate returns the error of invalid parameters, checked by RegisterGeneralizer
+func (p perturber) validate() error {
+	return p.err
+}
+
+func newPerturber(source rand.Source, noise func(rng *rand.Rand, v float64) float64) perturber {
+	if source == nil {
+		var seed [8]byte
+		if _, err := crand.Read(seed[:]); err != nil {
+			panic(err)
+		}
+		source = rand.NewSource(int64(binary.LittleEndian.Uint64(seed[:])))
+	}
+	// sources aren't safe for concurrent use, and values may be masked concurrently
+	var mu sync.Mutex
+	rng := rand.New(source)
+	return perturber{perturb: func(v float64) float64 {
+		mu.Lock()
+		defer mu.Unlock()
+		return noise(rng, v)
+	}}
+}
+
+func (p perturber) GeneralizeString(i string) (string, bool) {
+	if p.err != nil {
+		return "", false
+	}
+	number := strings.Replace(strings.TrimSpace(i), ",", "", -1)
+	v, err := strconv.ParseFloat(number, 64)
+	if err != nil || math.IsInf(v, 0) || math.IsNaN(v) {
+		return "", false
+	}
+	if strings.ContainsAny(number, "eE") {
+		return strconv.FormatFloat(p.perturb(v), 'g', -1, 64), true
+	}
+	// keep the precision of the string
+	decimals := 0
+	if dot := strings.IndexByte(number, '.'); dot >= 0 {
+		decimals = len(number) - dot - 1
+	}
+	return strconv.FormatFloat(p.perturb(v), 'f', decimals, 64), true
+}
+
+func (p perturber) GeneralizeValue(v interface{}) (interface{}, bool) {
+	if p.err != nil {
+		return nil, false
+	}
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return convertNumber(math.Round(p.perturb(float64(value.Int()))), value.Type()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return convertNumber(math.Round(p.perturb(float64(value.Uint()))), value.Type()), true
+	case reflect.Float32, reflect.Float64:
+		return convertNumber(p.perturb(value.Float()), value.Type()), true
+	default:
+		return nil, false
+	}
+}
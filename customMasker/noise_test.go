@@ -0,0 +1,197 @@
+package customMasker
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestNoise_Reproducible(t *testing.T) {
+	tests := []struct {
+		name string
+		new  func(source rand.Source) Generalizer
+	}{
+		{name: "Laplace", new: func(source rand.Source) Generalizer { return LaplaceNoise(1, 0.5, source) }},
+		{name: "Gaussian", new: func(source rand.Source) Generalizer { return GaussianNoise(1, 0.5, 1e-5, source) }},
+		{name: "Jitter", new: func(source rand.Source) Generalizer { return Jitter(0.05, source) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, second := tt.new(rand.NewSource(42)), tt.new(rand.NewSource(42))
+			for _, v := range []float64{0, 100, 52345.5, -7} {
+				a, _ := first.GeneralizeValue(v)
+				b, _ := second.GeneralizeValue(v)
+				if a != b {
+					t.Errorf("GeneralizeValue(%v) = %v and %v, want the same noise with the same seed", v, a, b)
+				}
+			}
+			if got, _ := tt.new(rand.NewSource(42)).GeneralizeValue(100.0); got == 100.0 {
+				t.Errorf("GeneralizeValue() = %v, want noise", got)
+			}
+		})
+	}
+}
+
+func TestNoise_Distribution(t *testing.T) {
+	const samples = 20000
+	tests := []struct {
+		name     string
+		g        Generalizer
+		wantMean float64
+		wantStd  float64
+	}{
+		// the Laplace distribution of scale b has a standard deviation of b*sqrt(2)
+		{name: "Laplace", g: LaplaceNoise(1, 0.5, rand.NewSource(1)), wantMean: 100, wantStd: 2 * math.Sqrt2},
+		{name: "Gaussian", g: GaussianNoise(1, 0.5, 1e-5, rand.NewSource(1)), wantMean: 100, wantStd: 2 * math.Sqrt(2*math.Log(1.25/1e-5))},
+		// uniform factors between 0.9 and 1.1 have a standard deviation of 0.1/sqrt(3)
+		{name: "Jitter", g: Jitter(0.1, rand.NewSource(1)), wantMean: 100, wantStd: 10 / math.Sqrt(3)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sum, sumSquares float64
+			for i := 0; i < samples; i++ {
+				v, ok := tt.g.GeneralizeValue(100.0)
+				if !ok {
+					t.Fatalf("GeneralizeValue() ok = false")
+				}
+				sum += v.(float64)
+				sumSquares += v.(float64) * v.(float64)
+			}
+			mean := sum / samples
+			std := math.Sqrt(sumSquares/samples - mean*mean)
+			if math.Abs(mean-tt.wantMean) > 0.05*tt.wantStd+0.1 {
+				t.Errorf("mean = %v, want %v", mean, tt.wantMean)
+			}
+			if math.Abs(std-tt.wantStd) > 0.05*tt.wantStd {
+				t.Errorf("standard deviation = %v, want %v", std, tt.wantStd)
+			}
+		})
+	}
+}
+
+func TestNoise_Types(t *testing.T) {
+	jitter := Jitter(0.5, rand.NewSource(7))
+	for i := 0; i < 100; i++ {
+		v, ok := jitter.GeneralizeValue(uint8(3))
+		if !ok {
+			t.Fatalf("GeneralizeValue() ok = false for uint8")
+		}
+		if _, isUint8 := v.(uint8); !isUint8 {
+			t.Fatalf("GeneralizeValue() = %T, want uint8", v)
+		}
+	}
+	if v, _ := LaplaceNoise(1000, 0.1, rand.NewSource(7)).GeneralizeValue(uint(1)); v.(uint) > math.MaxUint32 {
+		t.Errorf("GeneralizeValue() = %v, want unsigned integers to stay above 0", v)
+	}
+	if _, ok := jitter.GeneralizeValue("100"); ok {
+		t.Errorf("GeneralizeValue() ok = true for a string, want false")
+	}
+
+	got, ok := jitter.GeneralizeString("1,234.50")
+	if _, err := strconv.ParseFloat(got, 64); !ok || err != nil || len(got) < 4 || got[len(got)-3] != '.' {
+		t.Errorf("GeneralizeString() = %v, want a number with 2 decimals", got)
+	}
+	if _, ok := jitter.GeneralizeString("many"); ok {
+		t.Errorf("GeneralizeString() ok = true for a word, want false")
+	}
+}
+
+func TestNoise_IntegerLimits(t *testing.T) {
+	jitter := Jitter(0.5, rand.NewSource(1))
+	// noise of a scale of 10^9 pushes small integers to their limits
+	laplace := LaplaceNoise(1, 1e-9, rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		if v, _ := jitter.GeneralizeValue(int8(120)); v.(int8) < 60 {
+			t.Fatalf("GeneralizeValue(int8(120)) = %v, want a value clamped to int8", v)
+		}
+		if v, _ := jitter.GeneralizeValue(int8(-120)); v.(int8) > -60 {
+			t.Fatalf("GeneralizeValue(int8(-120)) = %v, want a value clamped to int8", v)
+		}
+		if v, _ := jitter.GeneralizeValue(uint8(250)); v.(uint8) < 125 {
+			t.Fatalf("GeneralizeValue(uint8(250)) = %v, want a value clamped to uint8", v)
+		}
+		if v, _ := laplace.GeneralizeValue(uint8(250)); v != uint8(0) && v != uint8(math.MaxUint8) {
+			t.Fatalf("GeneralizeValue(uint8(250)) = %v, want a value clamped to uint8", v)
+		}
+		if v, _ := laplace.GeneralizeValue(int8(120)); v != int8(math.MinInt8) && v != int8(math.MaxInt8) {
+			t.Fatalf("GeneralizeValue(int8(120)) = %v, want a value clamped to int8", v)
+		}
+	}
+
+	tests := []struct {
+		f    float64
+		v    interface{}
+		want interface{}
+	}{
+		{f: 300, v: int8(0), want: int8(math.MaxInt8)},
+		{f: -300, v: int8(0), want: int8(math.MinInt8)},
+		{f: 300, v: uint8(0), want: uint8(math.MaxUint8)},
+		{f: -3, v: uint8(0), want: uint8(0)},
+		{f: 1e20, v: int64(0), want: int64(math.MaxInt64)},
+		{f: -1e20, v: int64(0), want: int64(math.MinInt64)},
+		{f: 1e20, v: uint64(0), want: uint64(math.MaxUint64)},
+		{f: 42.9, v: int16(0), want: int16(42)},
+	}
+	for _, tt := range tests {
+		if got := convertNumber(tt.f, reflect.TypeOf(tt.v)); got != tt.want {
+			t.Errorf("convertNumber(%v) = %v, want %v", tt.f, got, tt.want)
+		}
+	}
+}
+
+func TestSignificantFigures(t *testing.T) {
+	tests := []struct {
+		figures int
+		input   string
+		want    string
+	}{
+		{figures: 2, input: "52345", want: "52000"},
+		{figures: 3, input: "0.012345", want: "0.012300"},
+		{figures: 1, input: "-87", want: "-90"},
+		{figures: 2, input: "0", want: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got, _ := SignificantFigures(tt.figures).GeneralizeString(tt.input); got != tt.want {
+				t.Errorf("GeneralizeString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got, _ := SignificantFigures(2).GeneralizeValue(int64(52345)); got != int64(52000) {
+		t.Errorf("GeneralizeValue() = %v, want 52000", got)
+	}
+	if got, _ := SignificantFigures(3).GeneralizeValue(float32(3.14159)); math.Abs(float64(got.(float32))-3.14) > 1e-6 {
+		t.Errorf("GeneralizeValue() = %v, want 3.14", got)
+	}
+}
+
+func TestNoise_InvalidParameters(t *testing.T) {
+	tests := []struct {
+		name string
+		g    Generalizer
+	}{
+		{name: "Zero Epsilon", g: LaplaceNoise(1, 0, nil)},
+		{name: "Negative Epsilon", g: GaussianNoise(1, -1, 1e-5, nil)},
+		{name: "Negative Sensitivity", g: LaplaceNoise(-1, 0.5, nil)},
+		{name: "NaN Sensitivity", g: LaplaceNoise(math.NaN(), 0.5, nil)},
+		{name: "Zero Delta", g: GaussianNoise(1, 0.5, 0, nil)},
+		{name: "Delta Of One", g: GaussianNoise(1, 0.5, 1, nil)},
+		{name: "Negative Jitter", g: Jitter(-0.1, nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := NewMasker().RegisterGeneralizer(Mtype("noisy"), tt.g); !errors.Is(err, ErrInvalidNoise) {
+				t.Errorf("Masker.RegisterGeneralizer() error = %v, want ErrInvalidNoise", err)
+			}
+			if got, ok := tt.g.GeneralizeValue(100.0); ok {
+				t.Errorf("GeneralizeValue() = %v, want no value with invalid parameters", got)
+			}
+		})
+	}
+	if err := NewMasker().RegisterGeneralizer(Mtype("noisy"), LaplaceNoise(0, 0.5, nil)); err != nil {
+		t.Errorf("Masker.RegisterGeneralizer() error = %v, want no error without sensitivity", err)
+	}
+}
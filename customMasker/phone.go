@@ -0,0 +1,135 @@
+package customMasker
+
+import (
+	"regexp"
+	"strings"
+)
+
+// PhonePolicy configures phone number masking
+type PhonePolicy struct {
+	// Region is the ISO 3166-1 alpha-2 code of the country used to parse numbers in national format
+	Region string
+	// KeepLast is the number of trailing digits left in the clear. At least half of the national number is masked
+	KeepLast int
+}
+
+// defaultPhonePolicy parses national numbers as Taiwanese ones, like Telephone
+var defaultPhonePolicy = PhonePolicy{Region: "TW", KeepLast: 4}
+
+var phoneExtensionRegex = regexp.MustCompile(`(?i)\s*(?:ext\.?|x|#)\s*\d+$`)
+
+// UpdatePhonePolicy updates the policy used to mask phone numbers
+func (m *Masker) UpdatePhonePolicy(policy PhonePolicy) {
+	m.phone = policy
+}
+
+// Phone keep the country code and the last digits of a phone number in national or international format, mask the
+// other digits and keep the formatting. Extensions are masked. Unrecognized numbers are masked entirely.
+//
+// Example:
+//
+//	input: +1 (415) 555-2671
+//	output: +1 (***) ***-2671
+//	input: 0912-345-678
+//	output: 0***-**5-678
+func (m *Masker) Phone(i string) string {
+	if i == "" {
+		return ""
+	}
+
+	number, extension := i, ""
+	if loc := phoneExtensionRegex.FindStringIndex(i); loc != nil {
+		number, extension = i[:loc[0]], i[loc[0]:]
+	}
+
+	kept, nsnLength, ok := m.phone.parse(number)
+	if !ok {
+		return m.MaskWithSpec(alphanumericSpec, i)
+	}
+
+	keepLast := clamp(m.phone.KeepLast, 0, nsnLength/2)
+	var digits int
+	for _, c := range number {
+		if c >= '0' && c <= '9' {
+			digits++
+		}
+	}
+
+	var b strings.Builder
+	var idx int
+	for _, c := range number {
+		if c < '0' || c > '9' {
+			b.WriteRune(c)
+			continue
+		}
+		if idx < kept || idx >= digits-keepLast {
+			b.WriteRune(c)
+		} else {
+			b.WriteString(maskGlyph(m.glyphs(), string(c), idx-kept))
+		}
+		idx++
+	}
+	b.WriteString(m.MaskWithSpec(MaskSpec{Classes: CDigits}, extension))
+	return b.String()
+}
+
+// parse recognizes a phone number, and returns the number of leading digits which are international or trunk prefixes
+// and the length of the national significant number
+func (p PhonePolicy) parse(number string) (kept int, nsnLength int, ok bool) {
+	trimmed := strings.TrimSpace(number)
+	var digits strings.Builder
+	for idx, c := range trimmed {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c == '+' && idx == 0:
+		case strings.ContainsRune(" -.()/", c):
+		default:
+			return 0, 0, false
+		}
+	}
+	d := digits.String()
+
+	home, hasHome := phoneRegions[strings.ToUpper(p.Region)]
+	international := strings.HasPrefix(trimmed, "+")
+	switch {
+	case international:
+	case strings.HasPrefix(d, "00"):
+		international, kept = true, 2
+	case hasHome && home.callingCode == "1" && strings.HasPrefix(d, "011"):
+		international, kept = true, 3
+	}
+
+	if !international {
+		if !hasHome {
+			return 0, 0, false
+		}
+		return nationalNumber(d, []phoneRegion{home})
+	}
+
+	// calling codes are prefix free, so at most one of them matches
+	for l := 1; l <= 3 && kept+l <= len(d); l++ {
+		regions, ok := callingCodes[d[kept:kept+l]]
+		if !ok {
+			continue
+		}
+		trunk, nsnLength, ok := nationalNumber(d[kept+l:], regions)
+		return kept + l + trunk, nsnLength, ok
+	}
+	return 0, 0, false
+}
+
+// nationalNumber checks the length of a number in national format, which may start with a trunk prefix
+func nationalNumber(d string, regions []phoneRegion) (trunk int, nsnLength int, ok bool) {
+	for _, region := range regions {
+		if region.trunkPrefix != "" && strings.HasPrefix(d, region.trunkPrefix) {
+			if l := len(d) - len(region.trunkPrefix); l >= region.minLength && l <= region.maxLength {
+				return len(region.trunkPrefix), l, true
+			}
+		}
+		if len(d) >= region.minLength && len(d) <= region.maxLength {
+			return 0, len(d), true
+		}
+	}
+	return 0, 0, false
+}
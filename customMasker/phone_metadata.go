@@ -0,0 +1,67 @@
+package customMasker
+
+// phoneRegion holds the numbering metadata of a country
+type phoneRegion struct {
+	// callingCode is the international calling code, without "+"
+	callingCode string
+	// trunkPrefix is dialled before national numbers within the country
+	trunkPrefix string
+	// minLength and maxLength bound the length of the national significant number
+	minLength int
+	maxLength int
+}
+
+// phoneRegions maps ISO 3166-1 alpha-2 region codes to their numbering metadata
+var phoneRegions = map[string]phoneRegion{
+	"AE": {callingCode: "971", trunkPrefix: "0", minLength: 8, maxLength: 9},
+	"AR": {callingCode: "54", trunkPrefix: "0", minLength: 10, maxLength: 11},
+	"AT": {callingCode: "43", trunkPrefix: "0", minLength: 4, maxLength: 13},
+	"AU": {callingCode: "61", trunkPrefix: "0", minLength: 9, maxLength: 9},
+	"BE": {callingCode: "32", trunkPrefix: "0", minLength: 8, maxLength: 9},
+	"BR": {callingCode: "55", trunkPrefix: "0", minLength: 10, maxLength: 11},
+	"CA": {callingCode: "1", trunkPrefix: "1", minLength: 10, maxLength: 10},
+	"CH": {callingCode: "41", trunkPrefix: "0", minLength: 9, maxLength: 9},
+	"CN": {callingCode: "86", trunkPrefix: "0", minLength: 10, maxLength: 11},
+	"DE": {callingCode: "49", trunkPrefix: "0", minLength: 6, maxLength: 13},
+	"DK": {callingCode: "45", minLength: 8, maxLength: 8},
+	"ES": {callingCode: "34", minLength: 9, maxLength: 9},
+	"FI": {callingCode: "358", trunkPrefix: "0", minLength: 5, maxLength: 12},
+	"FR": {callingCode: "33", trunkPrefix: "0", minLength: 9, maxLength: 9},
+	"GB": {callingCode: "44", trunkPrefix: "0", minLength: 9, maxLength: 10},
+	"HK": {callingCode: "852", minLength: 8, maxLength: 8},
+	"ID": {callingCode: "62", trunkPrefix: "0", minLength: 9, maxLength: 12},
+	"IE": {callingCode: "353", trunkPrefix: "0", minLength: 7, maxLength: 9},
+	"IL": {callingCode: "972", trunkPrefix: "0", minLength: 8, maxLength: 9},
+	"IN": {callingCode: "91", trunkPrefix: "0", minLength: 10, maxLength: 10},
+	"IT": {callingCode: "39", minLength: 6, maxLength: 11},
+	"JP": {callingCode: "81", trunkPrefix: "0", minLength: 9, maxLength: 10},
+	"KR": {callingCode: "82", trunkPrefix: "0", minLength: 8, maxLength: 10},
+	"MX": {callingCode: "52", minLength: 10, maxLength: 10},
+	"MY": {callingCode: "60", trunkPrefix: "0", minLength: 9, maxLength: 10},
+	"NG": {callingCode: "234", trunkPrefix: "0", minLength: 8, maxLength: 10},
+	"NL": {callingCode: "31", trunkPrefix: "0", minLength: 9, maxLength: 9},
+	"NO": {callingCode: "47", minLength: 8, maxLength: 8},
+	"NZ": {callingCode: "64", trunkPrefix: "0", minLength: 8, maxLength: 10},
+	"PH": {callingCode: "63", trunkPrefix: "0", minLength: 10, maxLength: 10},
+	"PL": {callingCode: "48", minLength: 9, maxLength: 9},
+	"PT": {callingCode: "351", minLength: 9, maxLength: 9},
+	"RU": {callingCode: "7", trunkPrefix: "8", minLength: 10, maxLength: 10},
+	"SA": {callingCode: "966", trunkPrefix: "0", minLength: 9, maxLength: 9},
+	"SE": {callingCode: "46", trunkPrefix: "0", minLength: 7, maxLength: 9},
+	"SG": {callingCode: "65", minLength: 8, maxLength: 8},
+	"TH": {callingCode: "66", trunkPrefix: "0", minLength: 8, maxLength: 9},
+	"TR": {callingCode: "90", trunkPrefix: "0", minLength: 10, maxLength: 10},
+	"TW": {callingCode: "886", trunkPrefix: "0", minLength: 8, maxLength: 9},
+	"US": {callingCode: "1", trunkPrefix: "1", minLength: 10, maxLength: 10},
+	"VN": {callingCode: "84", trunkPrefix: "0", minLength: 9, maxLength: 10},
+	"ZA": {callingCode: "27", trunkPrefix: "0", minLength: 9, maxLength: 9},
+}
+
+// callingCodes maps international calling codes to the regions sharing them
+var callingCodes = map[string][]phoneRegion{}
+
+func init() {
+	for _, region := range phoneRegions {
+		callingCodes[region.callingCode] = append(callingCodes[region.callingCode], region)
+	}
+}
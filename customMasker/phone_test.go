@@ -0,0 +1,38 @@
+package customMasker
+
+import "testing"
+
+func TestMasker_Phone(t *testing.T) {
+	us := NewMasker()
+	us.UpdatePhonePolicy(PhonePolicy{Region: "US", KeepLast: 4})
+
+	tests := []struct {
+		name string
+		m    *Masker
+		i    string
+		want string
+	}{
+		{name: "Empty Input", m: NewMasker(), i: "", want: ""},
+		{name: "International", m: NewMasker(), i: "+1 (415) 555-2671", want: "+1 (***) ***-2671"},
+		{name: "International Taiwan Mobile", m: NewMasker(), i: "+886 912-345-678", want: "+886 ***-**5-678"},
+		{name: "International Trunk Prefix", m: NewMasker(), i: "+44 (0)20 7946 0958", want: "+44 (0)** **** 0958"},
+		{name: "International Access Code", m: NewMasker(), i: "0049 30 901820", want: "0049 ** **1820"},
+		{name: "North American Access Code", m: us, i: "011 81 3-1234-5678", want: "011 81 *-****-5678"},
+		{name: "National Format", m: NewMasker(), i: "0912-345-678", want: "0***-**5-678"},
+		{name: "National Format Of Region", m: us, i: "(415) 555-2671", want: "(***) ***-2671"},
+		{name: "E.164", m: NewMasker(), i: "+819012345678", want: "+81******5678"},
+		{name: "Extension", m: us, i: "+1 415 555 2671 ext. 123", want: "+1 *** *** 2671 ext. ***"},
+		{name: "Short National Number", m: NewMasker(), i: "+65 6123 4567", want: "+65 **** 4567"},
+		{name: "Unknown Calling Code", m: NewMasker(), i: "+999 1234 5678", want: "+*** **** ****"},
+		{name: "Invalid Length", m: NewMasker(), i: "+1 415 555", want: "+* *** ***"},
+		{name: "Not A Phone Number", m: NewMasker(), i: "call me 0912", want: "**** ** ****"},
+		{name: "Keep Less Than Half", m: &Masker{mask: "*", phone: PhonePolicy{Region: "TW", KeepLast: 8}}, i: "0227993078", want: "0*****3078"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Phone(tt.i); got != tt.want {
+				t.Errorf("Masker.Phone() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package customMasker
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// defaultPseudonymLength is the number of hex digits of pseudonyms, 64 bits of the HMAC
+const defaultPseudonymLength = 16
+
+// PseudonymPolicy configures pseudonymization. Values are replaced with the HMAC-SHA256 of the key, so the same value
+// always gets the same pseudonym as long as the key doesn't change, and pseudonyms can't be reversed without the key.
+type PseudonymPolicy struct {
+	// Key is the HMAC key. Use at least 32 random bytes, and keep it secret: anyone knowing the key can check whether
+	// a pseudonym belongs to a guessed value. Without key, values are masked entirely.
+	Key []byte
+	// Keys provides the key when Key is empty. Pseudonyms then carry the ID of the active key after the prefix, e.g.
+	// "usr_2024-01:3f9a6c0e5b7d2a41", and VerifyPseudonym checks them with the key of their ID after a rotation.
+	Keys KeyProvider
+	// Prefix is prepended to pseudonyms, e.g. "usr_"
+	Prefix string
+	// Length is the number of hex digits of pseudonyms, 16 by default and at most 64
+	Length int
+	// CaseInsensitive gives the same pseudonym to values which only differ by case or surrounding spaces, like email
+	// addresses
+	CaseInsensitive bool
+}
+
+// UpdatePseudonymPolicy updates the policy used to pseudonymize values
+func (m *Masker) UpdatePseudonymPolicy(policy PseudonymPolicy) {
+	policy.Key = append([]byte(nil), policy.Key...)
+	m.pseudonym = policy
+}
+
+// Pseudonym replaces a value with a stable token derived from the HMAC-SHA256 of the key of the pseudonym policy.
+// Values are masked entirely if the policy has no key.
+//
+// Example:
+//
+//	input: dummy@dummy.com
+//	output: usr_3f9a6c0e5b7d2a41
+func (m *Masker) Pseudonym(i string) string {
+	return m.pseudonym.token(m, m.pseudonym.Prefix, i)
+}
+
+// RegisterPseudonym registers a user-defined mask type pseudonymizing values with the key of the pseudonym policy of
+// this masker, and its own prefix
+//
+// Example:
+//
+//	masker.RegisterPseudonym(customMasker.Mtype("account"), "acct_")
+func (m *Masker) RegisterPseudonym(t Mtype, prefix string) error {
+	if err := m.registerBound(t, func(m *Masker, i string) string {
+		return m.pseudonym.token(m, prefix, i)
+	}); err != nil {
+		return err
+	}
+	m.registry.registerMatchType(t)
+	return nil
+}
+
+func (p PseudonymPolicy) token(m *Masker, prefix string, i string) string {
+	if i == "" {
+		return ""
+	}
+	keyID, key, err := p.activeKey()
+	if err != nil {
+		return m.MaskWithSpec(MaskSpec{}, i)
+	}
+	if keyID != "" {
+		prefix += keyID + ":"
+	}
+	return prefix + p.digest(deriveKey(key, keyPurposePseudonym), i)[:p.length()]
+}
+
+// VerifyPseudonym reports whether the pseudonym, with the prefix of the pseudonym policy, is the pseudonym of the
+// value. Pseudonyms carrying a key ID are checked with the key of their ID, so pseudonyms computed before a rotation
+// are still verified. The digest must have the length of the policy: truncated pseudonyms are rejected.
+func (m *Masker) VerifyPseudonym(value string, pseudonym string) (bool, error) {
+	p := m.pseudonym
+	if !strings.HasPrefix(pseudonym, p.Prefix) {
+		return false, nil
+	}
+	digest := pseudonym[len(p.Prefix):]
+	var key []byte
+	if sep := strings.LastIndex(digest, ":"); sep >= 0 && len(p.Key) == 0 {
+		if p.Keys == nil {
+			return false, fmt.Errorf("%w: %q", ErrUnknownKeyID, digest[:sep])
+		}
+		var err error
+		if key, err = p.Keys.Key(digest[:sep]); err != nil {
+			return false, err
+		}
+		digest = digest[sep+1:]
+	} else {
+		_, activeKey, err := p.activeKey()
+		if err != nil {
+			return false, err
+		}
+		key = activeKey
+	}
+	expected := p.digest(deriveKey(key, keyPurposePseudonym), value)[:p.length()]
+	return hmac.Equal([]byte(digest), []byte(expected)), nil
+}
+
+// length returns the number of hex digits of pseudonyms
+func (p PseudonymPolicy) length() int {
+	if p.Length <= 0 {
+		return defaultPseudonymLength
+	}
+	return clamp(p.Length, 1, sha256.Size*2)
+}
+
+// activeKey returns the key of the policy, or the active key of its provider and the ID to carry in pseudonyms
+func (p PseudonymPolicy) activeKey() (string, []byte, error) {
+	if len(p.Key) > 0 {
+		return "", p.Key, nil
+	}
+	if p.Keys == nil {
+		return "", nil, ErrNoActiveKey
+	}
+	return p.Keys.ActiveKey()
+}
+
+func (p PseudonymPolicy) digest(key []byte, i string) string {
+	if p.CaseInsensitive {
+		i = strings.ToLower(strings.TrimSpace(i))
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(i))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,65 @@
+package customMasker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMasker_Pseudonym(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	m := NewMasker()
+	m.UpdatePseudonymPolicy(PseudonymPolicy{Key: key, Prefix: "usr_", CaseInsensitive: true})
+	got := m.Pseudonym("dummy@dummy.com")
+	if want := "usr_"; !strings.HasPrefix(got, want) || len(got) != len(want)+defaultPseudonymLength {
+		t.Fatalf("Masker.Pseudonym() = %v, want %v followed by %d hex digits", got, want, defaultPseudonymLength)
+	}
+	if again := m.Pseudonym(" Dummy@Dummy.com"); again != got {
+		t.Errorf("Masker.Pseudonym() = %v, want %v for the same value", again, got)
+	}
+	if other := m.Pseudonym("other@dummy.com"); other == got {
+		t.Errorf("Masker.Pseudonym() = %v for different values", other)
+	}
+
+	rotated := NewMasker()
+	rotated.UpdatePseudonymPolicy(PseudonymPolicy{Key: []byte("another key of the masker, 32 b."), Prefix: "usr_"})
+	if other := rotated.Pseudonym("dummy@dummy.com"); other == got {
+		t.Errorf("Masker.Pseudonym() = %v with different keys", other)
+	}
+
+	short := NewMasker()
+	short.UpdatePseudonymPolicy(PseudonymPolicy{Key: key, Length: 8})
+	if got := short.Pseudonym("dummy@dummy.com"); len(got) != 8 {
+		t.Errorf("Masker.Pseudonym() = %v, want 8 hex digits", got)
+	}
+
+	if err := m.RegisterPseudonym(Mtype("account"), "acct_"); err != nil {
+		t.Fatalf("Masker.RegisterPseudonym() error = %v", err)
+	}
+	if got := m.String(Mtype("account"), "12345", ""); !strings.HasPrefix(got, "acct_") {
+		t.Errorf("Masker.String() = %v, want acct_ prefix", got)
+	}
+}
+
+func TestMasker_PseudonymWithoutKey(t *testing.T) {
+	if got, want := NewMasker().Pseudonym("dummy@dummy.com"), "***************"; got != want {
+		t.Errorf("Masker.Pseudonym() = %v, want %v", got, want)
+	}
+}
+
+func TestMasker_VerifyPseudonym(t *testing.T) {
+	m := NewMasker()
+	m.UpdatePseudonymPolicy(PseudonymPolicy{Key: []byte("0123456789abcdef0123456789abcdef"), Prefix: "usr_"})
+	pseudonym := m.Pseudonym("dummy@dummy.com")
+	if ok, err := m.VerifyPseudonym("dummy@dummy.com", pseudonym); err != nil || !ok {
+		t.Fatalf("Masker.VerifyPseudonym() = %v, %v, want true", ok, err)
+	}
+	for _, truncated := range []string{pseudonym[:len(pseudonym)-1], pseudonym[:len("usr_")+1], "usr_"} {
+		if ok, _ := m.VerifyPseudonym("dummy@dummy.com", truncated); ok {
+			t.Errorf("Masker.VerifyPseudonym(%v) = true, want false for truncated pseudonyms", truncated)
+		}
+	}
+	if ok, _ := m.VerifyPseudonym("dummy@dummy.com", pseudonym+"0"); ok {
+		t.Errorf("Masker.VerifyPseudonym() = true, want false for longer pseudonyms")
+	}
+}
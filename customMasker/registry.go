@@ -0,0 +1,252 @@
+package customMasker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// MaskFunc masks the input string for a user-defined mask type
+type MaskFunc func(i string) string
+
+var (
+	// ErrDuplicateMaskType is returned when registering a built-in or already registered mask type
+	ErrDuplicateMaskType = errors.New("mask type already registered")
+
+	// ErrUnknownMaskType is returned when validating a mask type which is neither built-in nor registered
+	ErrUnknownMaskType = errors.New("unknown mask type")
+
+	// ErrInvalidMaskType is returned when registering an empty mask type or a nil mask function
+	ErrInvalidMaskType = errors.New("invalid mask type")
+)
+
+var builtinMaskTypes = map[Mtype]bool{
+	MPassword:          true,
+	MName:              true,
+	MAddress:           true,
+	MEmail:             true,
+	MMobile:            true,
+	MTelephone:         true,
+	MPhone:             true,
+	MID:                true,
+	MCreditCard:        true,
+	MURL:               true,
+	MDSN:               true,
+	MSecret:            true,
+	MPseudonym:         true,
+	MEncrypt:           true,
+	MTokenDigits:       true,
+	MTokenAlphanumeric: true,
+	MFakeName:          true,
+	MFakeEmail:         true,
+	MFakePhone:         true,
+	MFakeAddress:       true,
+	MFakeCreditCard:    true,
+	MYear:              true,
+	MAgeBand:           true,
+	MPostalPrefix:      true,
+	MGeoGrid:           true,
+	MReference:         true,
+	MHashSHA256:        true,
+	MHashBLAKE2b:       true,
+}
+
+// maskRegistry holds user-defined mask types. It is safe for concurrent use.
+type maskRegistry struct {
+	mu    sync.RWMutex
+	funcs map[Mtype]MaskFunc
+	// valueFuncs mask the typed values of user-defined mask types which support them
+	valueFuncs map[Mtype]valueMaskFunc
+	// boundFuncs mask with the masker resolved at call time, so the per-type masking characters of a masker apply
+	boundFuncs map[Mtype]boundMaskFunc
+	// matchTypes replace values with digests, pseudonyms or references, see MasksEachMatch
+	matchTypes map[Mtype]bool
+}
+
+// boundMaskFunc masks the input string with the masker masking it
+type boundMaskFunc func(m *Masker, i string) string
+
+// valueMaskFunc masks a typed value into a value of the same type, ok is false for unsupported types
+type valueMaskFunc func(v interface{}) (masked interface{}, ok bool)
+
+func (r *maskRegistry) register(t Mtype, fn MaskFunc) error {
+	if t == "" || fn == nil {
+		return fmt.Errorf("%w: %q", ErrInvalidMaskType, t)
+	}
+	if builtinMaskTypes[t] {
+		return fmt.Errorf("%w: %q is a built-in mask type", ErrDuplicateMaskType, t)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.funcs[t]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicateMaskType, t)
+	}
+	if r.funcs == nil {
+		r.funcs = map[Mtype]MaskFunc{}
+	}
+	r.funcs[t] = fn
+	return nil
+}
+
+func (r *maskRegistry) registerValue(t Mtype, fn valueMaskFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.valueFuncs == nil {
+		r.valueFuncs = map[Mtype]valueMaskFunc{}
+	}
+	r.valueFuncs[t] = fn
+}
+
+func (r *maskRegistry) lookupValue(t Mtype) (valueMaskFunc, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	fn, ok := r.valueFuncs[t]
+	return fn, ok
+}
+
+func (r *maskRegistry) registerBound(t Mtype, fn boundMaskFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.boundFuncs == nil {
+		r.boundFuncs = map[Mtype]boundMaskFunc{}
+	}
+	r.boundFuncs[t] = fn
+}
+
+func (r *maskRegistry) lookupBound(t Mtype) (boundMaskFunc, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	fn, ok := r.boundFuncs[t]
+	return fn, ok
+}
+
+func (r *maskRegistry) registerMatchType(t Mtype) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.matchTypes == nil {
+		r.matchTypes = map[Mtype]bool{}
+	}
+	r.matchTypes[t] = true
+}
+
+func (r *maskRegistry) isMatchType(t Mtype) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.matchTypes[t]
+}
+
+func (r *maskRegistry) lookup(t Mtype) (MaskFunc, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	fn, ok := r.funcs[t]
+	return fn, ok
+}
+
+var globalRegistry = &maskRegistry{}
+
+// RegisterMaskType registers a user-defined mask type for all maskers. Once registered, the mask type can be used
+// anywhere a built-in mask type is accepted.
+//
+// Example:
+//
+//	customMasker.RegisterMaskType(customMasker.Mtype("iban"), func(i string) string {
+//		return i[:4] + "****"
+//	})
+func RegisterMaskType(t Mtype, fn func(i string) string) error {
+	return globalRegistry.register(t, fn)
+}
+
+// ValidateMaskType returns ErrUnknownMaskType if t is neither built-in nor registered for all maskers. The empty mask
+// type is valid and masks with the default filtered label.
+func ValidateMaskType(t Mtype) error {
+	if t == "" || builtinMaskTypes[t] {
+		return nil
+	}
+	if _, ok := globalRegistry.lookup(t); ok {
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrUnknownMaskType, t)
+}
+
+// RegisterMaskType registers a user-defined mask type for this masker only. It takes precedence over a mask type of
+// the same name registered for all maskers.
+func (m *Masker) RegisterMaskType(t Mtype, fn func(i string) string) error {
+	if m.registry == nil {
+		m.registry = &maskRegistry{}
+	}
+	return m.registry.register(t, fn)
+}
+
+// ValidateMaskType returns ErrUnknownMaskType if t is neither built-in nor registered for this masker or all maskers
+func (m *Masker) ValidateMaskType(t Mtype) error {
+	if _, ok := m.lookupMaskType(t); ok {
+		return nil
+	}
+	return ValidateMaskType(t)
+}
+
+// registerBound registers a user-defined mask type for this masker only, masking with the masker of the call rather
+// than m
+func (m *Masker) registerBound(t Mtype, fn boundMaskFunc) error {
+	if err := m.RegisterMaskType(t, func(i string) string {
+		return fn(m, i)
+	}); err != nil {
+		return err
+	}
+	m.registry.registerBound(t, fn)
+	return nil
+}
+
+func (m *Masker) lookupMaskType(t Mtype) (MaskFunc, bool) {
+	if m.registry == nil {
+		return nil, false
+	}
+	if fn, ok := m.registry.lookupBound(t); ok {
+		return func(i string) string {
+			return fn(m, i)
+		}, true
+	}
+	return m.registry.lookup(t)
+}
+
+// lookupMaskType finds the mask function of a user-defined mask type, preferring the ones registered on m
+func lookupMaskType(m MaskerInterface, t Mtype) (MaskFunc, bool) {
+	if r, ok := m.(interface {
+		lookupMaskType(t Mtype) (MaskFunc, bool)
+	}); ok {
+		if fn, ok := r.lookupMaskType(t); ok {
+			return fn, true
+		}
+	}
+	return globalRegistry.lookup(t)
+}
+
+// matchMaskTypes are the built-in mask types replacing values with digests, pseudonyms, ciphertexts, tokens or
+// references
+var matchMaskTypes = map[Mtype]bool{
+	MPseudonym:         true,
+	MEncrypt:           true,
+	MTokenDigits:       true,
+	MTokenAlphanumeric: true,
+	MReference:         true,
+	MHashSHA256:        true,
+	MHashBLAKE2b:       true,
+}
+
+// MasksEachMatch reports whether t is a built-in mask type replacing values with a digest, a pseudonym, a ciphertext, a
+// token or a reference. Value and regex filters mask each match of these mask types on its own: the masking of the
+// whole string would give different matches the same replacement, and couldn't be unmasked.
+func MasksEachMatch(t Mtype) bool {
+	return matchMaskTypes[t]
+}
+
+// MasksEachMatch reports whether t masks each match of value and regex filters on its own: the mask types of
+// MasksEachMatch, and the mask types registered on the masker with RegisterHash, RegisterPseudonym or
+// RegisterReference.
+func (m *Masker) MasksEachMatch(t Mtype) bool {
+	if MasksEachMatch(t) {
+		return true
+	}
+	return m.registry != nil && m.registry.isMatchType(t)
+}
@@ -0,0 +1,111 @@
+package customMasker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRegisterMaskType(t *testing.T) {
+	iban := Mtype("test-iban")
+	if err := RegisterMaskType(iban, func(i string) string { return i[:4] + strings.Repeat("*", len(i)-4) }); err != nil {
+		t.Fatalf("RegisterMaskType() error = %v", err)
+	}
+
+	t.Run("Masks Registered Type", func(t *testing.T) {
+		if got := NewMasker().String(iban, "DE89370400440532013000", "[filtered]"); got != "DE89******************" {
+			t.Errorf("Masker.String() = %v, want %v", got, "DE89******************")
+		}
+		if got := String(iban, "GB82WEST"); got != "GB82****" {
+			t.Errorf("String() = %v, want %v", got, "GB82****")
+		}
+	})
+
+	t.Run("Duplicate Registration", func(t *testing.T) {
+		err := RegisterMaskType(iban, func(i string) string { return i })
+		if !errors.Is(err, ErrDuplicateMaskType) {
+			t.Errorf("RegisterMaskType() error = %v, want %v", err, ErrDuplicateMaskType)
+		}
+	})
+
+	t.Run("Built-in Type", func(t *testing.T) {
+		err := RegisterMaskType(MEmail, func(i string) string { return i })
+		if !errors.Is(err, ErrDuplicateMaskType) {
+			t.Errorf("RegisterMaskType() error = %v, want %v", err, ErrDuplicateMaskType)
+		}
+	})
+
+	t.Run("Invalid Registration", func(t *testing.T) {
+		if err := RegisterMaskType("", func(i string) string { return i }); !errors.Is(err, ErrInvalidMaskType) {
+			t.Errorf("RegisterMaskType() error = %v, want %v", err, ErrInvalidMaskType)
+		}
+		if err := RegisterMaskType(Mtype("test-nil"), nil); !errors.Is(err, ErrInvalidMaskType) {
+			t.Errorf("RegisterMaskType() error = %v, want %v", err, ErrInvalidMaskType)
+		}
+	})
+}
+
+func TestMasker_RegisterMaskType(t *testing.T) {
+	shared := Mtype("test-shared")
+	local := Mtype("test-local")
+	if err := RegisterMaskType(shared, func(i string) string { return "global" }); err != nil {
+		t.Fatalf("RegisterMaskType() error = %v", err)
+	}
+
+	m := NewMasker()
+	if err := m.RegisterMaskType(shared, func(i string) string { return "instance" }); err != nil {
+		t.Fatalf("Masker.RegisterMaskType() error = %v", err)
+	}
+	if err := m.RegisterMaskType(local, func(i string) string { return "local" }); err != nil {
+		t.Fatalf("Masker.RegisterMaskType() error = %v", err)
+	}
+	if err := m.RegisterMaskType(local, func(i string) string { return "local" }); !errors.Is(err, ErrDuplicateMaskType) {
+		t.Errorf("Masker.RegisterMaskType() error = %v, want %v", err, ErrDuplicateMaskType)
+	}
+
+	tests := []struct {
+		name string
+		m    *Masker
+		t    Mtype
+		want string
+	}{
+		{name: "Instance Overrides Global", m: m, t: shared, want: "instance"},
+		{name: "Instance Only", m: m, t: local, want: "local"},
+		{name: "Global From Other Instance", m: NewMasker(), t: shared, want: "global"},
+		{name: "Not Registered On Other Instance", m: NewMasker(), t: local, want: "[filtered]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.String(tt.t, "abc", "[filtered]"); got != tt.want {
+				t.Errorf("Masker.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMaskType(t *testing.T) {
+	m := NewMasker()
+	if err := m.RegisterMaskType(Mtype("test-validate"), func(i string) string { return i }); err != nil {
+		t.Fatalf("Masker.RegisterMaskType() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		t       Mtype
+		m       *Masker
+		wantErr error
+	}{
+		{name: "Default", t: "", m: NewMasker()},
+		{name: "Built-in", t: MCreditCard, m: NewMasker()},
+		{name: "Registered On Instance", t: Mtype("test-validate"), m: m},
+		{name: "Registered On Other Instance", t: Mtype("test-validate"), m: NewMasker(), wantErr: ErrUnknownMaskType},
+		{name: "Unknown", t: Mtype("test-unknown"), m: NewMasker(), wantErr: ErrUnknownMaskType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.m.ValidateMaskType(tt.t); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Masker.ValidateMaskType() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package customMasker
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// referenceLength is the number of random bytes of references, written as hex digits
+const referenceLength = 8
+
+// maxReferenceAttempts bounds the random draws of a new reference, for namespaces whose references are nearly all used
+const maxReferenceAttempts = 100
+
+// minSessionKeyLength is the least number of bytes of the key of a session
+const minSessionKeyLength = 16
+
+// ErrInvalidSessionKey is returned by NewSession for a store without key, or a key shorter than 16 bytes
+var ErrInvalidSessionKey = errors.New("invalid session key")
+
+// ErrReferencesExhausted is returned, and kept by the session, when every reference of a namespace is used, e.g. by
+// more than 127 identifiers of an int8 field. Values which can't get a reference are masked entirely.
+var ErrReferencesExhausted = errors.New("no unused reference left")
+
+// Mapping maps an original identifier of a namespace to its reference. Originals are kept as their HMAC-SHA256 with
+// the key of the session, so the mappings alone don't reveal them, even for guessable identifiers.
+type Mapping struct {
+	Namespace string `json:"ns"`
+	Digest    string `json:"digest"`
+	Reference string `json:"ref"`
+}
+
+// MappingStore persists the mappings of a session, so incremental exports reuse the references of previous exports
+type MappingStore interface {
+	// Load returns the mappings saved before
+	Load() ([]Mapping, error)
+	// Save persists a new mapping
+	Save(mapping Mapping) error
+}
+
+// Session maps each original identifier to the same random reference everywhere it appears, across the MaskDetails
+// calls of all masking instances sharing the session, e.g. to keep the foreign keys of related tables consistent.
+// References are random, so they can't be reversed or recomputed without the mappings. It is safe for concurrent use.
+type Session struct {
+	mu    sync.Mutex
+	key   []byte
+	store MappingStore
+	refs  map[string]string
+	used  map[string]bool
+	err   error
+}
+
+// NewSession returns a session saving its mappings to the store and reusing the mappings saved before. Originals are
+// mapped by their HMAC with key, which must be at least 16 bytes long and the same for every session reusing the
+// mappings. Pass a nil store to keep the mappings in memory only, with a nil key for a random one.
+func NewSession(store MappingStore, key []byte) (*Session, error) {
+	if len(key) == 0 && store == nil {
+		key = make([]byte, sha256.Size)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+	}
+	if len(key) < minSessionKeyLength {
+		return nil, fmt.Errorf("%w: %d bytes, want at least %d", ErrInvalidSessionKey, len(key), minSessionKeyLength)
+	}
+	s := &Session{
+		key:   append([]byte(nil), key...),
+		store: store,
+		refs:  map[string]string{},
+		used:  map[string]bool{},
+	}
+	if store == nil {
+		return s, nil
+	}
+	mappings, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	for _, mapping := range mappings {
+		s.refs[mapping.Namespace+"\x00"+mapping.Digest] = mapping.Reference
+		s.used[mapping.Namespace+"\x00"+mapping.Reference] = true
+	}
+	return s, nil
+}
+
+// Reference returns the reference of an original identifier of the namespace, creating one with the prefix if the
+// identifier wasn't seen before
+func (s *Session) Reference(namespace string, prefix string, original string) (string, error) {
+	random := make([]byte, referenceLength)
+	return s.reference(namespace, original, maxReferenceAttempts, func() (string, error) {
+		if _, err := rand.Read(random); err != nil {
+			return "", err
+		}
+		return prefix + hex.EncodeToString(random), nil
+	})
+}
+
+// NumericReference returns the numeric reference, between 1 and max, of an original integer identifier of the
+// namespace, creating one if the identifier wasn't seen before. Numeric references are mapped apart from the
+// references of strings. New references follow a random one until an unused one is found, so every reference up to
+// max is used before ErrReferencesExhausted is returned.
+func (s *Session) NumericReference(namespace string, original string, max uint64) (uint64, error) {
+	if max == 0 {
+		return 0, fmt.Errorf("invalid maximum numeric reference %d", max)
+	}
+	attempts := maxReferenceAttempts
+	if max <= 1<<20 {
+		attempts = int(max)
+	}
+	bound := new(big.Int).SetUint64(max)
+	var next uint64
+	started := false
+	ref, err := s.reference(namespace+"#numeric", original, attempts, func() (string, error) {
+		if !started {
+			n, err := rand.Int(rand.Reader, bound)
+			if err != nil {
+				return "", err
+			}
+			next, started = n.Uint64(), true
+		}
+		ref := next%max + 1
+		next = ref
+		return strconv.FormatUint(ref, 10), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(ref, 10, 64)
+}
+
+func (s *Session) reference(namespace string, original string, attempts int,
+	newReference func() (string, error)) (string, error) {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(original))
+	mapping := Mapping{Namespace: namespace, Digest: hex.EncodeToString(mac.Sum(nil))}
+	key := mapping.Namespace + "\x00" + mapping.Digest
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ref, ok := s.refs[key]; ok {
+		return ref, nil
+	}
+	for attempt := 0; mapping.Reference == "" || s.used[mapping.Namespace+"\x00"+mapping.Reference]; attempt++ {
+		if attempt == attempts {
+			s.err = fmt.Errorf("%w in namespace %q", ErrReferencesExhausted, namespace)
+			return "", s.err
+		}
+		ref, err := newReference()
+		if err != nil {
+			return "", err
+		}
+		mapping.Reference = ref
+	}
+	if s.store != nil {
+		if err := s.store.Save(mapping); err != nil {
+			s.err = err
+			return "", err
+		}
+	}
+	s.refs[key] = mapping.Reference
+	s.used[mapping.Namespace+"\x00"+mapping.Reference] = true
+	return mapping.Reference, nil
+}
+
+// Err returns the last error saving a mapping or creating a reference, like ErrReferencesExhausted. Values without
+// reference are masked entirely.
+func (s *Session) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// FileMappingStore is a MappingStore appending mappings to a file, one JSON object per line. The file holds keyed
+// digests of identifiers rather than identifiers: without the key of the session, they can't be matched with guesses.
+type FileMappingStore struct {
+	path string
+	mu   sync.Mutex
+	file *os.File
+}
+
+var _ MappingStore = (*FileMappingStore)(nil)
+
+// NewFileMappingStore opens the mapping file at path, creating it if needed
+func NewFileMappingStore(path string) (*FileMappingStore, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileMappingStore{path: path, file: file}, nil
+}
+
+// Load reads the mappings of the file
+func (f *FileMappingStore) Load() ([]Mapping, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.file.Seek(0, 0); err != nil {
+		return nil, err
+	}
+	var mappings []Mapping
+	scanner := bufio.NewScanner(f.file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var mapping Mapping
+		if err := json.Unmarshal(scanner.Bytes(), &mapping); err != nil {
+			return nil, fmt.Errorf("mapping file %s:%d: %w", f.path, line, err)
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings, scanner.Err()
+}
+
+// Save appends a mapping to the file
+func (f *FileMappingStore) Save(mapping Mapping) error {
+	line, err := json.Marshal(mapping)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, err = f.file.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the mapping file
+func (f *FileMappingStore) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+// UseSession makes the masker replace values of MReference, and of mask types registered with RegisterReference, with
+// the references of the session
+func (m *Masker) UseSession(session *Session) {
+	m.session = session
+}
+
+// Reference replaces an identifier with its reference in the session of the masker, the same for every occurrence of
+// the identifier in all masking instances sharing the session. Values are masked entirely without session.
+//
+// Example:
+//
+//	input: 42
+//	output: ref_5c1f0a9e7b3d2846
+func (m *Masker) Reference(i string) string {
+	return m.reference(string(MReference), "ref_", i)
+}
+
+// RegisterReference registers a user-defined mask type replacing identifiers with references of their own namespace
+// and prefix in the session of this masker. Use one mask type per kind of identifier, like users or orders, on all
+// the fields holding these identifiers.
+//
+// Example:
+//
+//	masker.RegisterReference(customMasker.Mtype("user"), "usr_")
+func (m *Masker) RegisterReference(t Mtype, prefix string) error {
+	if err := m.registerBound(t, func(m *Masker, i string) string {
+		return m.reference(string(t), prefix, i)
+	}); err != nil {
+		return err
+	}
+	m.registry.registerValue(t, func(v interface{}) (interface{}, bool) {
+		return m.referenceValue(string(t), v)
+	})
+	m.registry.registerMatchType(t)
+	return nil
+}
+
+func (m *Masker) reference(namespace string, prefix string, i string) string {
+	if i == "" {
+		return ""
+	}
+	if m.session == nil {
+		return m.MaskWithSpec(MaskSpec{}, i)
+	}
+	ref, err := m.session.Reference(namespace, prefix, i)
+	if err != nil {
+		return m.MaskWithSpec(MaskSpec{}, i)
+	}
+	return ref
+}
+
+// referenceValue replaces an integer identifier with a numeric reference of the same type, so integer foreign keys keep
+// their type. Negative references aren't used. Identifiers left without reference once the references of the type are
+// exhausted are zeroed, and the session keeps ErrReferencesExhausted.
+func (m *Masker) referenceValue(namespace string, v interface{}) (interface{}, bool) {
+	if m.session == nil {
+		return nil, false
+	}
+	value := reflect.ValueOf(v)
+	var original string
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		original = strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		original = strconv.FormatUint(value.Uint(), 10)
+	default:
+		return nil, false
+	}
+	max := uint64(1)<<uint(value.Type().Bits()) - 1
+	if value.Kind() < reflect.Uint {
+		max >>= 1
+	}
+	ref, err := m.session.NumericReference(namespace, original, max)
+	if err != nil {
+		return nil, false
+	}
+	return reflect.ValueOf(ref).Convert(value.Type()).Interface(), true
+}
@@ -0,0 +1,187 @@
+package customMasker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var testSessionKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestMasker_Reference(t *testing.T) {
+	session, err := NewSession(nil, nil)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	users, orders := NewMasker(), NewMasker()
+	for _, m := range []*Masker{users, orders} {
+		m.UseSession(session)
+		if err := m.RegisterReference(Mtype("user"), "usr_"); err != nil {
+			t.Fatalf("Masker.RegisterReference() error = %v", err)
+		}
+	}
+
+	ref := users.String(Mtype("user"), "42", "")
+	if !regexp.MustCompile(`^usr_[0-9a-f]{16}$`).MatchString(ref) {
+		t.Fatalf("Masker.String() = %v, want a usr_ reference", ref)
+	}
+	if got := orders.String(Mtype("user"), "42", ""); got != ref {
+		t.Errorf("Masker.String() = %v, want %v in every masker of the session", got, ref)
+	}
+	if got := users.String(Mtype("user"), "43", ""); got == ref {
+		t.Errorf("Masker.String() = %v for another identifier, want another reference", got)
+	}
+	if got := users.Reference("42"); !strings.HasPrefix(got, "ref_") || got == ref {
+		t.Errorf("Masker.Reference() = %v, want a reference of its own namespace", got)
+	}
+	if got, want := NewMasker().Reference("42"), "**"; got != want {
+		t.Errorf("Masker.Reference() = %v, want %v without session", got, want)
+	}
+
+	number, ok := users.MaskValue(Mtype("user"), int32(42))
+	if !ok || number.(int32) <= 0 {
+		t.Fatalf("Masker.MaskValue() = %v, %v, want a positive int32 reference", number, ok)
+	}
+	if again, _ := orders.MaskValue(Mtype("user"), int32(42)); again != number {
+		t.Errorf("Masker.MaskValue() = %v, want %v in every masker of the session", again, number)
+	}
+	if other, _ := orders.MaskValue(Mtype("user"), int32(43)); other == number {
+		t.Errorf("Masker.MaskValue() = %v for another identifier, want another reference", other)
+	}
+	if small, ok := users.MaskValue(MReference, uint8(7)); !ok || small.(uint8) == 0 {
+		t.Errorf("Masker.MaskValue() = %v, %v, want a uint8 reference", small, ok)
+	}
+	if _, ok := users.MaskValue(Mtype("user"), 4.2); ok {
+		t.Errorf("Masker.MaskValue() ok = true for a float, want false")
+	}
+}
+
+func TestFileMappingStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mappings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mappings.jsonl")
+
+	store, err := NewFileMappingStore(path)
+	if err != nil {
+		t.Fatalf("NewFileMappingStore() error = %v", err)
+	}
+	session, err := NewSession(store, testSessionKey)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	ref, err := session.Reference("user", "usr_", "dummy@dummy.com")
+	if err != nil {
+		t.Fatalf("Session.Reference() error = %v", err)
+	}
+	number, err := session.NumericReference("user", "42", 1000)
+	if err != nil || number < 1 || number > 1000 {
+		t.Fatalf("Session.NumericReference() = %v, %v, want a reference between 1 and 1000", number, err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("FileMappingStore.Close() error = %v", err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "dummy@dummy.com") {
+		t.Errorf("mapping file = %s, want digests instead of identifiers", data)
+	}
+	if unkeyed := sha256.Sum256([]byte("42")); strings.Contains(string(data), hex.EncodeToString(unkeyed[:])) {
+		t.Errorf("mapping file = %s, want keyed digests which can't be matched by enumeration", data)
+	}
+
+	store, err = NewFileMappingStore(path)
+	if err != nil {
+		t.Fatalf("NewFileMappingStore() error = %v", err)
+	}
+	defer store.Close()
+	session, err = NewSession(store, testSessionKey)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	if got, err := session.Reference("user", "usr_", "dummy@dummy.com"); err != nil || got != ref {
+		t.Errorf("Session.Reference() = %v, %v, want %v from the mapping file", got, err, ref)
+	}
+	if got, err := session.NumericReference("user", "42", 1000); err != nil || got != number {
+		t.Errorf("Session.NumericReference() = %v, %v, want %v from the mapping file", got, err, number)
+	}
+	if _, err := session.Reference("user", "usr_", "other@dummy.com"); err != nil {
+		t.Errorf("Session.Reference() error = %v", err)
+	}
+	if err := session.Err(); err != nil {
+		t.Errorf("Session.Err() = %v", err)
+	}
+
+	otherKey, err := NewSession(store, []byte("another session key of 32 bytes."))
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	if got, _ := otherKey.Reference("user", "usr_", "dummy@dummy.com"); got == ref {
+		t.Errorf("Session.Reference() = %v with another key, want a new reference", got)
+	}
+	if _, err := NewSession(store, nil); !errors.Is(err, ErrInvalidSessionKey) {
+		t.Errorf("NewSession() error = %v, want ErrInvalidSessionKey for a store without key", err)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("{\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	invalid, err := NewFileMappingStore(path)
+	if err != nil {
+		t.Fatalf("NewFileMappingStore() error = %v", err)
+	}
+	defer invalid.Close()
+	if _, err := NewSession(invalid, testSessionKey); err == nil {
+		t.Errorf("NewSession() error = nil, want an error for an invalid mapping file")
+	}
+}
+
+func TestSession_Exhausted(t *testing.T) {
+	session, err := NewSession(nil, nil)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	if _, err := session.NumericReference("flag", "0", 1); err != nil {
+		t.Fatalf("Session.NumericReference() error = %v", err)
+	}
+	if _, err := session.NumericReference("flag", "1", 1); err == nil {
+		t.Errorf("Session.NumericReference() error = nil, want an error once all references are used")
+	}
+}
+
+func TestMasker_ReferenceValueExhausted(t *testing.T) {
+	session, err := NewSession(nil, nil)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	m := NewMasker()
+	m.UseSession(session)
+	seen := map[int8]bool{}
+	for i := 0; i < 127; i++ {
+		ref, ok := m.MaskValue(MReference, int8(i))
+		if !ok || ref.(int8) <= 0 || seen[ref.(int8)] {
+			t.Fatalf("Masker.MaskValue(%d) = %v, %v, want an unused positive int8 reference", i, ref, ok)
+		}
+		seen[ref.(int8)] = true
+	}
+	if err := session.Err(); err != nil {
+		t.Fatalf("Session.Err() = %v before the references are exhausted", err)
+	}
+	if ref, ok := m.MaskValue(MReference, int8(-1)); ok {
+		t.Errorf("Masker.MaskValue() = %v, want no reference once the int8 references are exhausted", ref)
+	}
+	if err := session.Err(); !errors.Is(err, ErrReferencesExhausted) {
+		t.Errorf("Session.Err() = %v, want ErrReferencesExhausted", err)
+	}
+}
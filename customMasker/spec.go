@@ -0,0 +1,187 @@
+package customMasker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CharClass is a set of character classes masked by a MaskSpec
+type CharClass uint8
+
+// Character classes of a MaskSpec
+const (
+	CLetters CharClass = 1 << iota
+	CDigits
+	CSymbols
+	CSpaces
+	CAll = CLetters | CDigits | CSymbols | CSpaces
+)
+
+// alphanumericSpec masks every letter and digit, keeping the format of values which aren't recognized
+var alphanumericSpec = MaskSpec{Classes: CLetters | CDigits}
+
+// ErrInvalidMaskSpec is returned when registering a MaskSpec with negative counts
+var ErrInvalidMaskSpec = errors.New("invalid mask spec")
+
+// MaskSpec declares which characters of a string are kept and which are masked. Characters are grapheme clusters, so
+// combining marks and emoji sequences are masked as a whole. Counts only include maskable characters: characters of
+// the masked classes which are not separators.
+//
+// Example:
+//
+//	spec := customMasker.MaskSpec{KeepLast: 4, Classes: customMasker.CDigits}
+//	spec.Mask("AB-1234-5678")
+//	// AB-****-5678
+type MaskSpec struct {
+	// KeepFirst is the number of leading maskable characters left in the clear
+	KeepFirst int
+	// KeepLast is the number of trailing maskable characters left in the clear
+	KeepLast int
+	// MaxMasked limits the number of characters masked after the leading kept ones. 0 masks all of them
+	MaxMasked int
+	// MaskLength replaces the masked characters with exactly MaskLength masking characters, hiding the original
+	// length. Separators between masked characters are dropped. 0 preserves the length
+	MaskLength int
+	// Classes are the masked character classes. Characters of other classes are left in the clear. 0 masks all classes
+	Classes CharClass
+	// Separators are characters which are never masked, e.g. " -"
+	Separators string
+	// Placeholder is the masking character. Empty uses the masking character of the masker
+	Placeholder MaskingCharacter
+}
+
+// Validate returns ErrInvalidMaskSpec if a count of the spec is negative
+func (s MaskSpec) Validate() error {
+	if s.KeepFirst < 0 || s.KeepLast < 0 || s.MaxMasked < 0 || s.MaskLength < 0 {
+		return fmt.Errorf("%w: negative count in %+v", ErrInvalidMaskSpec, s)
+	}
+	return nil
+}
+
+// Mask masks the input string with the placeholder of the spec, or "*" if it has none
+func (s MaskSpec) Mask(i string) string {
+	return s.apply(i, maskChars{chars: string(PStar)})
+}
+
+func (s MaskSpec) maskable(cluster string) bool {
+	c, _ := utf8.DecodeRuneInString(cluster)
+	if strings.Contains(s.Separators, cluster) {
+		return false
+	}
+	classes := s.Classes
+	if classes == 0 {
+		classes = CAll
+	}
+	switch {
+	case unicode.IsLetter(c) || unicode.IsMark(c):
+		return classes&CLetters != 0
+	case unicode.IsDigit(c):
+		return classes&CDigits != 0
+	case unicode.IsSpace(c):
+		return classes&CSpaces != 0
+	default:
+		return classes&CSymbols != 0
+	}
+}
+
+// apply masks the input string, using mask unless the spec has a placeholder
+func (s MaskSpec) apply(i string, mask maskChars) string {
+	if i == "" {
+		return ""
+	}
+	if s.Placeholder != "" {
+		mask = maskChars{chars: string(s.Placeholder)}
+	}
+
+	r := graphemes(i)
+	var positions []int
+	for idx, cluster := range r {
+		if s.maskable(cluster) {
+			positions = append(positions, idx)
+		}
+	}
+
+	n := len(positions)
+	start := clamp(s.KeepFirst, 0, n)
+	end := clamp(n-s.KeepLast, start, n)
+	if s.MaxMasked > 0 && end-start > s.MaxMasked {
+		end = start + s.MaxMasked
+	}
+
+	if s.MaskLength == 0 {
+		var b strings.Builder
+		next := start
+		for idx, cluster := range r {
+			if next < end && positions[next] == idx {
+				b.WriteString(maskGlyph(mask, cluster, next-start))
+				next++
+				continue
+			}
+			b.WriteString(cluster)
+		}
+		return b.String()
+	}
+
+	// with a fixed mask length, the masked run is replaced as a whole
+	from := len(r)
+	switch {
+	case start < n:
+		from = positions[start]
+	case n > 0:
+		from = positions[n-1] + 1
+	}
+	to := from
+	if end > start {
+		to = positions[end-1] + 1
+	}
+	// class-preserving masks follow the masked characters, then repeat the class of the last one
+	var b strings.Builder
+	b.WriteString(strings.Join(r[:from], ""))
+	for k := 0; k < s.MaskLength; k++ {
+		cluster := ""
+		if end > start {
+			cluster = r[positions[start+clamp(k, 0, end-start-1)]]
+		}
+		b.WriteString(maskGlyph(mask, cluster, k))
+	}
+	b.WriteString(strings.Join(r[to:], ""))
+	return b.String()
+}
+
+func clamp(v, lower, upper int) int {
+	if v < lower {
+		return lower
+	}
+	if v > upper {
+		return upper
+	}
+	return v
+}
+
+// RegisterMaskSpec registers a mask spec as a user-defined mask type for all maskers
+func RegisterMaskSpec(t Mtype, spec MaskSpec) error {
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+	return RegisterMaskType(t, spec.Mask)
+}
+
+// RegisterMaskSpec registers a mask spec as a user-defined mask type for this masker only. Unless the spec has a
+// placeholder, it masks with the masking character of the mask type, or else of the masker.
+func (m *Masker) RegisterMaskSpec(t Mtype, spec MaskSpec) error {
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+	return m.registerBound(t, func(m *Masker, i string) string {
+		return m.MaskWithSpec(spec, i)
+	})
+}
+
+// MaskWithSpec masks the input string with a mask spec. Unless the spec has a placeholder, it masks with the masking
+// character of the masker.
+func (m *Masker) MaskWithSpec(spec MaskSpec, i string) string {
+	return spec.apply(i, m.glyphs())
+}
@@ -0,0 +1,111 @@
+package customMasker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMaskSpec_Mask(t *testing.T) {
+	tests := []struct {
+		name string
+		spec MaskSpec
+		i    string
+		want string
+	}{
+		{
+			name: "Empty Input",
+			spec: MaskSpec{KeepLast: 4},
+			i:    "",
+			want: "",
+		},
+		{
+			name: "Mask Everything",
+			spec: MaskSpec{},
+			i:    "secret",
+			want: "******",
+		},
+		{
+			name: "Keep First And Last",
+			spec: MaskSpec{KeepFirst: 2, KeepLast: 2},
+			i:    "abcdefgh",
+			want: "ab****gh",
+		},
+		{
+			name: "Keep More Than Length",
+			spec: MaskSpec{KeepFirst: 4, KeepLast: 4},
+			i:    "abcdef",
+			want: "abcdef",
+		},
+		{
+			name: "Max Masked",
+			spec: MaskSpec{KeepFirst: 1, MaxMasked: 3},
+			i:    "abcdefgh",
+			want: "a***efgh",
+		},
+		{
+			name: "Fixed Mask Length",
+			spec: MaskSpec{KeepFirst: 2, MaskLength: 3},
+			i:    "abcdefgh",
+			want: "ab***",
+		},
+		{
+			name: "Fixed Mask Length Nothing To Mask",
+			spec: MaskSpec{KeepFirst: 6, MaskLength: 4},
+			i:    "A12",
+			want: "A12****",
+		},
+		{
+			name: "Digits Only",
+			spec: MaskSpec{KeepLast: 4, Classes: CDigits},
+			i:    "AB-1234-5678",
+			want: "AB-****-5678",
+		},
+		{
+			name: "Separators",
+			spec: MaskSpec{KeepFirst: 4, KeepLast: 4, Separators: " "},
+			i:    "4444 3333 2222 1111",
+			want: "4444 **** **** 1111",
+		},
+		{
+			name: "Separators Dropped With Fixed Mask Length",
+			spec: MaskSpec{KeepFirst: 4, KeepLast: 4, MaskLength: 4, Separators: " "},
+			i:    "4444 3333 2222 1111",
+			want: "4444 **** 1111",
+		},
+		{
+			name: "Letters Only",
+			spec: MaskSpec{KeepFirst: 1, Classes: CLetters},
+			i:    "John 2nd",
+			want: "J*** 2**",
+		},
+		{
+			name: "Placeholder",
+			spec: MaskSpec{KeepFirst: 3, Placeholder: PCross},
+			i:    "台北市內湖區",
+			want: "台北市xxx",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.spec.Mask(tt.i); got != tt.want {
+				t.Errorf("MaskSpec.Mask() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMasker_RegisterMaskSpec(t *testing.T) {
+	m := NewMasker()
+	last4 := Mtype("test-last4")
+	if err := m.RegisterMaskSpec(last4, MaskSpec{KeepLast: 4, Classes: CDigits}); err != nil {
+		t.Fatalf("Masker.RegisterMaskSpec() error = %v", err)
+	}
+	m.UpdateMaskingCharacter(PHyphen)
+	if got := m.String(last4, "0912345678", "[filtered]"); got != "------5678" {
+		t.Errorf("Masker.String() = %v, want %v", got, "------5678")
+	}
+
+	if err := m.RegisterMaskSpec(Mtype("test-negative"), MaskSpec{KeepFirst: -1}); !errors.Is(err, ErrInvalidMaskSpec) {
+		t.Errorf("Masker.RegisterMaskSpec() error = %v, want %v", err, ErrInvalidMaskSpec)
+	}
+}
@@ -0,0 +1,73 @@
+package customMasker
+
+import "strings"
+
+// TaiwanIDKind is the kind of a Taiwanese identification number
+type TaiwanIDKind int
+
+// Kinds of Taiwanese identification numbers
+const (
+	// TWInvalidID is not a valid identification number
+	TWInvalidID TaiwanIDKind = iota
+	// TWNationalID is a national identification number: a region letter, 1 or 2 for the gender, and 8 digits
+	TWNationalID
+	// TWResidentCertificate is the number of a resident certificate: a region letter, then A to D in the format used
+	// until 2020, or 8 or 9 since 2021, and 8 digits
+	TWResidentCertificate
+)
+
+// taiwanIDRegions maps the region letters of identification numbers to their codes used by the checksum
+var taiwanIDRegions = map[byte]int{
+	'A': 10, 'B': 11, 'C': 12, 'D': 13, 'E': 14, 'F': 15, 'G': 16, 'H': 17, 'I': 34, 'J': 18, 'K': 19, 'L': 20, 'M': 21,
+	'N': 22, 'O': 35, 'P': 23, 'Q': 24, 'R': 25, 'S': 26, 'T': 27, 'U': 28, 'V': 29, 'W': 32, 'X': 30, 'Y': 31, 'Z': 33,
+}
+
+// TaiwanID returns the kind of a Taiwanese national identification number or resident certificate number, after
+// validating its region letter and checksum. Letters are case-insensitive.
+func TaiwanID(i string) TaiwanIDKind {
+	if len(i) != 10 {
+		return TWInvalidID
+	}
+	id := strings.ToUpper(i)
+	region, ok := taiwanIDRegions[id[0]]
+	if !ok {
+		return TWInvalidID
+	}
+
+	kind := TWNationalID
+	var second int
+	switch c := id[1]; {
+	case c == '1' || c == '2':
+		second = int(c - '0')
+	case c == '8' || c == '9':
+		kind, second = TWResidentCertificate, int(c-'0')
+	case c >= 'A' && c <= 'D':
+		kind, second = TWResidentCertificate, taiwanIDRegions[c]%10
+	default:
+		return TWInvalidID
+	}
+
+	// the region code counts with weights 1 and 9, the next 8 characters with weights 8 to 1, and the check digit with 1
+	sum := region/10 + region%10*9 + second*8
+	for idx := 2; idx < 10; idx++ {
+		c := id[idx]
+		if c < '0' || c > '9' {
+			return TWInvalidID
+		}
+		weight := 9 - idx
+		if idx == 9 {
+			weight = 1
+		}
+		sum += int(c-'0') * weight
+	}
+	if sum%10 != 0 {
+		return TWInvalidID
+	}
+	return kind
+}
+
+// IsTaiwanID reports whether the string is a valid Taiwanese national identification number or resident certificate
+// number
+func IsTaiwanID(i string) bool {
+	return TaiwanID(i) != TWInvalidID
+}
@@ -0,0 +1,30 @@
+package customMasker
+
+import "testing"
+
+func TestTaiwanID(t *testing.T) {
+	tests := []struct {
+		name string
+		i    string
+		want TaiwanIDKind
+	}{
+		{name: "National ID", i: "A123456789", want: TWNationalID},
+		{name: "National ID Lower Case", i: "b234567894", want: TWNationalID},
+		{name: "Region Code Over 30", i: "Z199999990", want: TWNationalID},
+		{name: "Resident Certificate", i: "A800000014", want: TWResidentCertificate},
+		{name: "Resident Certificate Before 2021", i: "FA12345670", want: TWResidentCertificate},
+		{name: "Resident Certificate Before 2021 C", i: "AC01234567", want: TWResidentCertificate},
+		{name: "Wrong Checksum", i: "A123456788", want: TWInvalidID},
+		{name: "Wrong Gender Digit", i: "A323456789", want: TWInvalidID},
+		{name: "Wrong Region Letter", i: "1123456789", want: TWInvalidID},
+		{name: "Wrong Length", i: "A12345678", want: TWInvalidID},
+		{name: "Not Digits", i: "A1234567X9", want: TWInvalidID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TaiwanID(tt.i); got != tt.want {
+				t.Errorf("TaiwanID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
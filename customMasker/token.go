@@ -0,0 +1,212 @@
+package customMasker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrNoTokenizationKey is returned when detokenizing with a masker which has no tokenization key
+	ErrNoTokenizationKey = errors.New("masker has no tokenization key")
+
+	// ErrInvalidToken is returned when detokenizing a string which can't be a token of the mask type, e.g. because it
+	// is too short
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// tokenAlphabet is the alphabet of alphanumeric tokens, digits are numerals 0 to 9 for both alphabets
+const tokenAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// TokenPolicy configures format-preserving tokenization. Tokens are computed with the FF1 mode of NIST SP 800-38G:
+// the digits, or letters and digits, of a value are encrypted into as many digits, or letters and digits, so tokens
+// have the length and the separators of the values, and the key restores the values with Detokenize. The same value
+// always gets the same token with the same key and tweak.
+type TokenPolicy struct {
+	// Key is the AES-128, AES-192 or AES-256 key. Without key, values are masked entirely.
+	Key []byte
+	// Keys provides the key when Key is empty: the key of KeyID, or the key active when the policy is updated. Tokens
+	// can't carry the ID of their key without changing their format, so rotating the provider doesn't change the key
+	// of tokens until the policy is updated again.
+	Keys KeyProvider
+	// KeyID is the ID of the key of Keys
+	KeyID string
+	// Tweak changes the tokens of the same key, e.g. to give different tokens to the values of different tables
+	Tweak []byte
+	// Luhn keeps the Luhn check digit of digit tokens valid when the value has a valid check digit, e.g. for card
+	// numbers. The last digit isn't encrypted but computed from the others.
+	Luhn bool
+}
+
+// tokenizer holds the FF1 ciphers of the token policy of a masker
+type tokenizer struct {
+	policy       TokenPolicy
+	keyID        string
+	digits       *ff1
+	alphanumeric *ff1
+	luhn         bool
+}
+
+// UpdateTokenPolicy updates the key and the options used to tokenize values. It returns ErrInvalidEncryptionKey if
+// the key isn't an AES key, and the error of the key provider if it has no key of the policy.
+func (m *Masker) UpdateTokenPolicy(policy TokenPolicy) error {
+	key, keyID := policy.Key, policy.KeyID
+	if len(key) == 0 && policy.Keys != nil {
+		var err error
+		if keyID == "" {
+			keyID, key, err = policy.Keys.ActiveKey()
+		} else {
+			key, err = policy.Keys.Key(keyID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	subkey := key
+	if len(key) > 0 {
+		subkey = deriveKey(key, keyPurposeToken)
+	}
+	digits, err := newFF1(subkey, policy.Tweak, 10)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEncryptionKey, err)
+	}
+	alphanumeric, err := newFF1(subkey, policy.Tweak, len(tokenAlphabet))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEncryptionKey, err)
+	}
+	policy.Key = append([]byte(nil), policy.Key...)
+	m.tokens = tokenizer{policy: policy, keyID: keyID, digits: digits, alphanumeric: alphanumeric, luhn: policy.Luhn}
+	return nil
+}
+
+// TokenKeyID returns the ID of the key of tokens when the token policy takes its key from a key provider. Store it
+// with tokens to detokenize them after a rotation with a policy of the same KeyID.
+func (m *Masker) TokenKeyID() string {
+	return m.tokens.keyID
+}
+
+// TokenizeDigits replaces the digits of a value with a format-preserving token of the same number of digits. Other
+// characters are kept. Values of less than 6 digits, or masked by a masker without tokenization key, are masked
+// entirely.
+//
+// Example:
+//
+//	input: 4111-1111-1111-1111
+//	output: 7289-0635-8147-2201
+func (m *Masker) TokenizeDigits(i string) string {
+	token, err := m.tokens.transform(i, 10, true)
+	if err != nil {
+		return m.MaskWithSpec(MaskSpec{Classes: CDigits}, i)
+	}
+	return token
+}
+
+// TokenizeAlphanumeric replaces the ASCII letters and digits of a value with a format-preserving token of as many
+// letters and digits. Other characters are kept. Values of less than 4 letters and digits, or masked by a masker
+// without tokenization key, are masked entirely.
+//
+// Example:
+//
+//	input: AB12-CD34
+//	output: q7Zk-0fTw
+func (m *Masker) TokenizeAlphanumeric(i string) string {
+	token, err := m.tokens.transform(i, len(tokenAlphabet), true)
+	if err != nil {
+		return m.MaskWithSpec(alphanumericSpec, i)
+	}
+	return token
+}
+
+// Detokenize restores the original of a token of MTokenDigits or MTokenAlphanumeric. The masker must have the key,
+// tweak and Luhn option of the policy which computed the token.
+func (m *Masker) Detokenize(t Mtype, token string) (string, error) {
+	radix := 0
+	switch t {
+	case MTokenDigits:
+		radix = 10
+	case MTokenAlphanumeric:
+		radix = len(tokenAlphabet)
+	default:
+		return "", fmt.Errorf("%w: %q isn't a token mask type", ErrInvalidMaskType, t)
+	}
+	return m.tokens.transform(token, radix, false)
+}
+
+// transform encrypts or decrypts the numerals of the radix in the string, keeping other characters
+func (t tokenizer) transform(i string, radix int, encrypt bool) (string, error) {
+	if i == "" {
+		return "", nil
+	}
+	f := t.digits
+	if radix != 10 {
+		f = t.alphanumeric
+	}
+	if f == nil {
+		return "", ErrNoTokenizationKey
+	}
+
+	var positions []int
+	var numerals []int
+	for idx := 0; idx < len(i); idx++ {
+		if numeral := tokenNumeral(i[idx], radix); numeral >= 0 {
+			positions = append(positions, idx)
+			numerals = append(numerals, numeral)
+		}
+	}
+
+	luhn := t.luhn && radix == 10
+	body := numerals
+	if luhn && len(numerals) > 0 {
+		body = numerals[:len(numerals)-1]
+	}
+	var transformed []int
+	var err error
+	if encrypt {
+		transformed, err = f.encrypt(body)
+	} else {
+		transformed, err = f.decrypt(body)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if luhn {
+		// the check digit keeps its offset from the valid check digit, so valid values get valid tokens and the offset
+		// restores the check digit of invalid values
+		offset := numerals[len(numerals)-1] - luhnCheckDigit(body)
+		transformed = append(transformed, ((luhnCheckDigit(transformed)+offset)%10+10)%10)
+	}
+
+	b := []byte(i)
+	for idx, pos := range positions {
+		b[pos] = tokenAlphabet[transformed[idx]]
+	}
+	return string(b), nil
+}
+
+// tokenNumeral returns the numeral of the character in the alphabet of the radix, or -1
+func tokenNumeral(c byte, radix int) int {
+	if c >= 0x80 {
+		return -1
+	}
+	numeral := strings.IndexByte(tokenAlphabet, c)
+	if numeral >= radix {
+		return -1
+	}
+	return numeral
+}
+
+// luhnCheckDigit returns the digit which appended to the digits gives a valid Luhn check digit
+func luhnCheckDigit(digits []int) int {
+	sum := 0
+	for idx := len(digits) - 1; idx >= 0; idx-- {
+		d := digits[idx]
+		if (len(digits)-idx)%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return (10 - sum%10) % 10
+}
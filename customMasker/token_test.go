@@ -0,0 +1,128 @@
+package customMasker
+
+import (
+	"errors"
+	"testing"
+)
+
+var testTokenKey = []byte("0123456789abcdef")
+
+func TestMasker_Tokenize(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy TokenPolicy
+		t      Mtype
+		input  string
+	}{
+		{name: "Digits", t: MTokenDigits, input: "0912345678"},
+		{name: "Digits With Separators", t: MTokenDigits, input: "A123-456 789"},
+		{name: "Card Number With Luhn", policy: TokenPolicy{Luhn: true}, t: MTokenDigits, input: "4111 1111 1111 1111"},
+		{name: "Invalid Card Number With Luhn", policy: TokenPolicy{Luhn: true}, t: MTokenDigits, input: "4111 1111 1111 1112"},
+		{name: "Tweak", policy: TokenPolicy{Tweak: []byte("customers")}, t: MTokenDigits, input: "0912345678"},
+		{name: "Alphanumeric", t: MTokenAlphanumeric, input: "AB12-cd34"},
+		{name: "Alphanumeric Unicode", t: MTokenAlphanumeric, input: "王小明 user_42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMasker()
+			tt.policy.Key = testTokenKey
+			if err := m.UpdateTokenPolicy(tt.policy); err != nil {
+				t.Fatalf("Masker.UpdateTokenPolicy() error = %v", err)
+			}
+			token := m.String(tt.t, tt.input, "")
+			if token == tt.input {
+				t.Fatalf("Masker.String() = %v, want a token", token)
+			}
+			if len(token) != len(tt.input) {
+				t.Errorf("Masker.String() = %v, want the length of %v", token, tt.input)
+			}
+			radix := 10
+			if tt.t == MTokenAlphanumeric {
+				radix = len(tokenAlphabet)
+			}
+			for idx := 0; idx < len(tt.input); idx++ {
+				if (tokenNumeral(tt.input[idx], radix) >= 0) != (tokenNumeral(token[idx], radix) >= 0) {
+					t.Errorf("Masker.String() = %v, want the format of %v", token, tt.input)
+					break
+				}
+				if tokenNumeral(tt.input[idx], radix) < 0 && tt.input[idx] != token[idx] {
+					t.Errorf("Masker.String() = %v, want the separators of %v", token, tt.input)
+					break
+				}
+			}
+			if tt.policy.Luhn && LuhnValid(token) != LuhnValid(tt.input) {
+				t.Errorf("Masker.String() = %v, want Luhn validity of %v", token, tt.input)
+			}
+			if again := m.String(tt.t, tt.input, ""); again != token {
+				t.Errorf("Masker.String() = %v, then %v, want stable tokens", token, again)
+			}
+			if got, err := m.Detokenize(tt.t, token); err != nil || got != tt.input {
+				t.Errorf("Masker.Detokenize() = %v, %v, want %v", got, err, tt.input)
+			}
+		})
+	}
+}
+
+func TestMasker_TokenizeTweak(t *testing.T) {
+	m := NewMasker()
+	if err := m.UpdateTokenPolicy(TokenPolicy{Key: testTokenKey}); err != nil {
+		t.Fatalf("Masker.UpdateTokenPolicy() error = %v", err)
+	}
+	tweaked := NewMasker()
+	if err := tweaked.UpdateTokenPolicy(TokenPolicy{Key: testTokenKey, Tweak: []byte("customers")}); err != nil {
+		t.Fatalf("Masker.UpdateTokenPolicy() error = %v", err)
+	}
+	if m.TokenizeDigits("0912345678") == tweaked.TokenizeDigits("0912345678") {
+		t.Errorf("Masker.TokenizeDigits() gives the same token with different tweaks")
+	}
+}
+
+func TestMasker_TokenizeMasked(t *testing.T) {
+	m := NewMasker()
+	tests := []struct {
+		name string
+		fn   func(string) string
+		in   string
+		want string
+	}{
+		{name: "Digits Without Key", fn: m.TokenizeDigits, in: "0912-345678", want: "****-******"},
+		{name: "Alphanumeric Without Key", fn: m.TokenizeAlphanumeric, in: "AB12-cd34", want: "****-****"},
+		{name: "Empty", fn: m.TokenizeDigits, in: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(tt.in); got != tt.want {
+				t.Errorf("Masker.Tokenize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if err := m.UpdateTokenPolicy(TokenPolicy{Key: testTokenKey}); err != nil {
+		t.Fatalf("Masker.UpdateTokenPolicy() error = %v", err)
+	}
+	if got, want := m.TokenizeDigits("12-345"), "**-***"; got != want {
+		t.Errorf("Masker.TokenizeDigits() = %v, want %v for too few digits", got, want)
+	}
+	if got, want := m.TokenizeAlphanumeric("a-1b"), "*-**"; got != want {
+		t.Errorf("Masker.TokenizeAlphanumeric() = %v, want %v for too few characters", got, want)
+	}
+}
+
+func TestMasker_Detokenize(t *testing.T) {
+	if _, err := NewMasker().Detokenize(MTokenDigits, "0912345678"); !errors.Is(err, ErrNoTokenizationKey) {
+		t.Errorf("Masker.Detokenize() error = %v, want ErrNoTokenizationKey", err)
+	}
+	m := NewMasker()
+	if err := m.UpdateTokenPolicy(TokenPolicy{Key: testTokenKey}); err != nil {
+		t.Fatalf("Masker.UpdateTokenPolicy() error = %v", err)
+	}
+	if _, err := m.Detokenize(MTokenDigits, "123"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Masker.Detokenize() error = %v, want ErrInvalidToken", err)
+	}
+	if _, err := m.Detokenize(MEmail, "0912345678"); !errors.Is(err, ErrInvalidMaskType) {
+		t.Errorf("Masker.Detokenize() error = %v, want ErrInvalidMaskType", err)
+	}
+	if err := m.UpdateTokenPolicy(TokenPolicy{Key: []byte("short")}); !errors.Is(err, ErrInvalidEncryptionKey) {
+		t.Errorf("Masker.UpdateTokenPolicy() error = %v, want ErrInvalidEncryptionKey", err)
+	}
+}
@@ -0,0 +1,140 @@
+package customMasker
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// URLFragmentAction selects how the fragment of a URL is masked
+type URLFragmentAction int
+
+// Fragment actions of a URLPolicy
+const (
+	// URLFragmentKeep leaves the fragment in the clear
+	URLFragmentKeep URLFragmentAction = iota
+	// URLFragmentMaskParams masks the sensitive parameters of fragments formatted like query strings, such as the
+	// access tokens of OAuth implicit grants
+	URLFragmentMaskParams
+	// URLFragmentMask masks the whole fragment
+	URLFragmentMask
+	// URLFragmentDrop removes the fragment
+	URLFragmentDrop
+)
+
+// URLPolicy configures URL masking. Passwords of the user information are always redacted.
+type URLPolicy struct {
+	// SensitiveParams are the names of query parameters whose values are masked, compared case-insensitively
+	SensitiveParams []string
+	// PathPatterns match path segments which are masked, e.g. API keys. Segments are matched unescaped
+	PathPatterns []*regexp.Regexp
+	// Fragment selects how the fragment is masked
+	Fragment URLFragmentAction
+}
+
+// DefaultURLPolicy masks common token, key and signature parameters, path segments which look like API keys, tokens
+// or hashes, and sensitive parameters of fragments
+var DefaultURLPolicy = URLPolicy{
+	SensitiveParams: []string{
+		"access_token", "refresh_token", "id_token", "token", "auth", "code", "session", "sessionid",
+		"api_key", "apikey", "key", "secret", "client_secret", "password", "passwd", "pwd",
+		"signature", "sig", "x-amz-signature", "x-amz-credential", "x-amz-security-token",
+		"x-goog-signature", "x-goog-credential",
+	},
+	PathPatterns: []*regexp.Regexp{
+		regexp.MustCompile(`^(?:sk|pk|rk)_(?:live|test)_[A-Za-z0-9]+$`),
+		regexp.MustCompile(`^gh[pousr]_[A-Za-z0-9]{36,}$`),
+		regexp.MustCompile(`^xox[abprs]-[A-Za-z0-9-]+$`),
+		regexp.MustCompile(`^AKIA[0-9A-Z]{16}$`),
+		regexp.MustCompile(`^eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*$`),
+		regexp.MustCompile(`^[A-Fa-f0-9]{32,}$`),
+	},
+	Fragment: URLFragmentMaskParams,
+}
+
+// urlMaskLength is the number of masking characters replacing masked values, which hides their length
+const urlMaskLength = 5
+
+// urlMask returns the masking characters replacing a masked value, escaped unless they are allowed in every part of
+// a URL
+func (m *Masker) urlMask() string {
+	mask := strLoop(m.glyphs(), urlMaskLength)
+	for _, c := range mask {
+		if !('a' <= c && c <= 'z') && !('A' <= c && c <= 'Z') && !('0' <= c && c <= '9') &&
+			!strings.ContainsRune("-._~!$'()*,", c) {
+			return url.QueryEscape(mask)
+		}
+	}
+	return mask
+}
+
+// UpdateURLPolicy updates the policy used to mask URLs
+func (m *Masker) UpdateURLPolicy(policy URLPolicy) {
+	m.url = policy
+}
+
+func (p URLPolicy) sensitive(param string) bool {
+	for _, name := range p.SensitiveParams {
+		if strings.EqualFold(name, param) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskParams masks the values of sensitive parameters of a raw query string, keeping their order and encoding
+func (m *Masker) maskParams(rawQuery string, sensitive func(name string) bool) string {
+	if rawQuery == "" {
+		return ""
+	}
+	pairs := strings.Split(rawQuery, "&")
+	for idx, pair := range pairs {
+		eq := strings.Index(pair, "=")
+		if eq < 0 || eq == len(pair)-1 {
+			continue
+		}
+		key := pair[:eq]
+		if name, err := url.QueryUnescape(key); err == nil && sensitive(name) {
+			pairs[idx] = key + "=" + m.urlMask()
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// maskPath masks the segments of an escaped path matching a path pattern
+func (m *Masker) maskPath(escapedPath string) string {
+	if len(m.url.PathPatterns) == 0 {
+		return escapedPath
+	}
+	segments := strings.Split(escapedPath, "/")
+	for idx, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil || unescaped == "" {
+			continue
+		}
+		for _, pattern := range m.url.PathPatterns {
+			if pattern.MatchString(unescaped) {
+				segments[idx] = m.urlMask()
+				break
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// maskFragment masks an escaped fragment with the fragment action of the policy
+func (m *Masker) maskFragment(escapedFragment string) string {
+	switch m.url.Fragment {
+	case URLFragmentMaskParams:
+		if strings.Contains(escapedFragment, "=") {
+			return m.maskParams(escapedFragment, m.url.sensitive)
+		}
+	case URLFragmentMask:
+		if escapedFragment != "" {
+			return m.urlMask()
+		}
+	case URLFragmentDrop:
+		return ""
+	}
+	return escapedFragment
+}
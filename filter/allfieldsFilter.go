@@ -3,6 +3,7 @@ package filter
 import "github.com/anu1097/golang-masking-tool/customMasker"
 
 type allFieldsFilter struct {
+	maskerBinding
 	mtype customMasker.Mtype
 }
 
@@ -19,13 +20,34 @@ func CustomAllFieldFilter(mtype customMasker.Mtype) *allFieldsFilter {
 }
 
 func (x *allFieldsFilter) ReplaceString(s string) string {
-	return GetFilteredLabel()
+	return x.label("all_fields", "", s)
+}
+
+func (x *allFieldsFilter) ReplaceStringWith(masker customMasker.MaskerInterface, s string) string {
+	return x.ReplaceString(s)
 }
 
 func (x *allFieldsFilter) MaskString(s string) string {
-	return customMaskerInstance.String(x.mtype, s, GetFilteredLabel())
+	return x.MaskStringWith(customMaskerInstance, s)
+}
+
+func (x *allFieldsFilter) MaskStringWith(masker customMasker.MaskerInterface, s string) string {
+	return x.mask(masker, "all_fields", x.mtype, s)
+}
+
+func (x *allFieldsFilter) MaskValue(masker customMasker.MaskerInterface, value interface{}) (interface{}, bool) {
+	return x.maskValue(masker, x.mtype, value)
 }
 
 func (x *allFieldsFilter) ShouldMask(fieldName string, value interface{}, tag string) bool {
 	return fieldName != ""
 }
+
+func (x *allFieldsFilter) MaskTypes() []customMasker.Mtype {
+	return []customMasker.Mtype{x.mtype}
+}
+
+func (x *allFieldsFilter) copyFilter() (Filter, *maskerBinding) {
+	copied := *x
+	return &copied, &copied.maskerBinding
+}
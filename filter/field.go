@@ -7,6 +7,7 @@ import (
 )
 
 type fieldFilter struct {
+	maskerBinding
 	target   string
 	maskType customMasker.Mtype
 }
@@ -27,18 +28,35 @@ func FieldFilter(target string) *fieldFilter {
 }
 
 func (x *fieldFilter) MaskString(s string) string {
-	return customMaskerInstance.String(x.maskType, s, GetFilteredLabel())
+	return x.MaskStringWith(customMaskerInstance, s)
+}
+
+func (x *fieldFilter) MaskStringWith(masker customMasker.MaskerInterface, s string) string {
+	return x.mask(masker, "field", x.maskType, s)
+}
+
+func (x *fieldFilter) MaskValue(masker customMasker.MaskerInterface, value interface{}) (interface{}, bool) {
+	return x.maskValue(masker, x.maskType, value)
 }
 
 func (x *fieldFilter) ReplaceString(s string) string {
 	return s
 }
 
+func (x *fieldFilter) ReplaceStringWith(masker customMasker.MaskerInterface, s string) string {
+	return s
+}
+
 func (x *fieldFilter) ShouldMask(fieldName string, value interface{}, tag string) bool {
 	return x.target == fieldName
 }
 
+func (x *fieldFilter) MaskTypes() []customMasker.Mtype {
+	return []customMasker.Mtype{x.maskType}
+}
+
 type fieldPrefixFilter struct {
+	maskerBinding
 	prefix   string
 	maskType customMasker.Mtype
 }
@@ -59,13 +77,39 @@ func CustomFieldPrefixFilter(prefix string, maskType customMasker.Mtype) *fieldP
 }
 
 func (x *fieldPrefixFilter) MaskString(s string) string {
-	return customMaskerInstance.String(x.maskType, s, GetFilteredLabel())
+	return x.MaskStringWith(customMaskerInstance, s)
+}
+
+func (x *fieldPrefixFilter) MaskStringWith(masker customMasker.MaskerInterface, s string) string {
+	return x.mask(masker, "field_prefix", x.maskType, s)
+}
+
+func (x *fieldPrefixFilter) MaskValue(masker customMasker.MaskerInterface, value interface{}) (interface{}, bool) {
+	return x.maskValue(masker, x.maskType, value)
 }
 
 func (x *fieldPrefixFilter) ReplaceString(s string) string {
 	return s
 }
 
+func (x *fieldPrefixFilter) ReplaceStringWith(masker customMasker.MaskerInterface, s string) string {
+	return s
+}
+
 func (x *fieldPrefixFilter) ShouldMask(fieldName string, value interface{}, tag string) bool {
 	return strings.HasPrefix(fieldName, x.prefix)
 }
+
+func (x *fieldPrefixFilter) MaskTypes() []customMasker.Mtype {
+	return []customMasker.Mtype{x.maskType}
+}
+
+func (x *fieldFilter) copyFilter() (Filter, *maskerBinding) {
+	copied := *x
+	return &copied, &copied.maskerBinding
+}
+
+func (x *fieldPrefixFilter) copyFilter() (Filter, *maskerBinding) {
+	copied := *x
+	return &copied, &copied.maskerBinding
+}
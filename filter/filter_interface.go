@@ -2,13 +2,154 @@ package filter
 
 import "github.com/anu1097/golang-masking-tool/customMasker"
 
-var customMaskerInstance *customMasker.Masker
+var customMaskerInstance customMasker.MaskerInterface = customMasker.NewMasker()
 
-// Sets Custom Masker Instance for all filters for A Masker Instance
-func SetCustomMaskerInstance(customMaskerI *customMasker.Masker) {
+// Sets Custom Masker Instance used by ReplaceString and MaskString of filters, outside of masking instances
+func SetCustomMaskerInstance(customMaskerI customMasker.MaskerInterface) {
 	customMaskerInstance = customMaskerI
 }
 
+// MaskerFilter is implemented by filters which delegate masking to a custom masker. Masking instances call these
+// methods with their own custom masker instead of ReplaceString and MaskString, so a filter shared by several masking
+// instances masks with the custom masker of each of them.
+type MaskerFilter interface {
+	ReplaceStringWith(masker customMasker.MaskerInterface, s string) string
+	MaskStringWith(masker customMasker.MaskerInterface, s string) string
+}
+
+var (
+	_ MaskerFilter = (*allFieldsFilter)(nil)
+	_ MaskerFilter = (*fieldFilter)(nil)
+	_ MaskerFilter = (*fieldPrefixFilter)(nil)
+	_ MaskerFilter = (*piiRegexFilter)(nil)
+	_ MaskerFilter = (*tagFilter)(nil)
+	_ MaskerFilter = (*typeFilter)(nil)
+	_ MaskerFilter = (*valueFilter)(nil)
+)
+
+// MaskTyper is implemented by filters masking with custom mask types. It returns the mask types used by the filter so
+// they can be validated before masking.
+type MaskTyper interface {
+	MaskTypes() []customMasker.Mtype
+}
+
+// ValueMasker is implemented by filters which can mask typed values, like ints, floats and time.Time, with their mask
+// type and the custom masker of the masking instance. Values of other types, or of mask types which can't mask them,
+// are replaced with empty values.
+type ValueMasker interface {
+	MaskValue(masker customMasker.MaskerInterface, value interface{}) (interface{}, bool)
+}
+
+// maskerBinding is embedded by filters delegating masking to a custom masker, to hold the mask spec, redaction label
+// and match masking attached to the filter
+type maskerBinding struct {
+	spec          *customMasker.MaskSpec
+	redactedLabel *string
+	maskMatches   bool
+}
+
+// mask masks the string with the attached label or mask spec, or else with the label or the mask type. filterName and
+// the mask type fill the placeholders of labels.
+func (x *maskerBinding) mask(masker customMasker.MaskerInterface, filterName string, maskType customMasker.Mtype, s string) string {
+	if x.redactedLabel != nil {
+		return RenderLabel(*x.redactedLabel, filterName, maskType, s)
+	}
+	if x.spec != nil {
+		if specMasker, ok := masker.(interface {
+			MaskWithSpec(spec customMasker.MaskSpec, i string) string
+		}); ok {
+			return specMasker.MaskWithSpec(*x.spec, s)
+		}
+		return x.spec.Mask(s)
+	}
+	if label, ok := GetTypeLabel(maskType); ok {
+		return RenderLabel(label, filterName, maskType, s)
+	}
+	return masker.String(maskType, s, RenderLabel(GetFilteredLabel(), filterName, maskType, s))
+}
+
+// maskValue masks a typed value with the mask type, unless a label or a mask spec is attached to the filter
+func (x *maskerBinding) maskValue(masker customMasker.MaskerInterface, maskType customMasker.Mtype, value interface{}) (interface{}, bool) {
+	if x.redactedLabel != nil || x.spec != nil {
+		return nil, false
+	}
+	if valueMasker, ok := masker.(interface {
+		MaskValue(t customMasker.Mtype, v interface{}) (interface{}, bool)
+	}); ok {
+		return valueMasker.MaskValue(maskType, value)
+	}
+	return nil, false
+}
+
+// label returns the label attached to the filter, or else the filtered label
+func (x *maskerBinding) label(filterName string, maskType customMasker.Mtype, s string) string {
+	if x.redactedLabel != nil {
+		return RenderLabel(*x.redactedLabel, filterName, maskType, s)
+	}
+	return RenderLabel(GetFilteredLabel(), filterName, maskType, s)
+}
+
+// withBinding returns a copy of a filter created by this package with its binding changed by set, so filters shared by
+// several masking instances are never changed in place. Other filters are returned unchanged.
+func withBinding(f Filter, set func(b *maskerBinding)) Filter {
+	copier, ok := f.(interface {
+		copyFilter() (Filter, *maskerBinding)
+	})
+	if !ok {
+		return f
+	}
+	copied, binding := copier.copyFilter()
+	set(binding)
+	return copied
+}
+
+// WithMaskSpec returns a copy of a filter created by this package with a mask spec attached. The copy masks with the
+// spec instead of its mask type. Other filters are returned unchanged.
+//
+// Example:
+//
+//	filter.WithMaskSpec(filter.FieldFilter("Iban"), customMasker.MaskSpec{KeepFirst: 4, KeepLast: 4})
+func WithMaskSpec(f Filter, spec customMasker.MaskSpec) Filter {
+	return withBinding(f, func(b *maskerBinding) {
+		b.spec = &spec
+	})
+}
+
+// replacement returns the masking of a match found in s by a value or regex filter. Matches are replaced with the
+// masking of the whole string s, unless MaskMatches was called on the filter or the mask type masks each match on its
+// own, e.g. hash and encryption mask types.
+func (x *maskerBinding) replacement(masker customMasker.MaskerInterface, filterName string, maskType customMasker.Mtype, s string, match string) string {
+	if x.maskMatches || x.spec == nil && x.redactedLabel == nil && masksEachMatch(masker, maskType) {
+		return x.mask(masker, filterName, maskType, match)
+	}
+	return x.mask(masker, filterName, maskType, s)
+}
+
+// masksEachMatch reports whether the masker masks each match of the mask type on its own
+func masksEachMatch(masker customMasker.MaskerInterface, maskType customMasker.Mtype) bool {
+	if matchMasker, ok := masker.(interface {
+		MasksEachMatch(t customMasker.Mtype) bool
+	}); ok {
+		return matchMasker.MasksEachMatch(maskType)
+	}
+	return customMasker.MasksEachMatch(maskType)
+}
+
+// MaskMatches returns a copy of a value or regex filter created by this package masking each match with its mask
+// type, instead of replacing each match with the masking of the whole string. Other filters are returned unchanged.
+// Mask types reported by customMasker.MasksEachMatch, like hash and encryption mask types, mask each match without it.
+//
+// Example:
+//
+//	input: call 0978978978 or 0912345678
+//	filter.CustomPhoneFilter(customMasker.MMobile): call ************* or call *************
+//	filter.MaskMatches(filter.CustomPhoneFilter(customMasker.MMobile)): call 0978***978 or 0912***678
+func MaskMatches(f Filter) Filter {
+	return withBinding(f, func(b *maskerBinding) {
+		b.maskMatches = true
+	})
+}
+
 type Filter interface {
 	// ReplaceString is called when checking string type. The argument is the value to be checked, and the return value should be the value to be replaced. If nothing needs to be done, the method should return the argument as is. This method is intended for the case where you want to hide a part of a string.
 	ReplaceString(s string) string
@@ -36,6 +177,27 @@ func (x Filters) MaskString(s string) string {
 	return s
 }
 
+// ReplaceStringWith calls ReplaceString of each filter, or ReplaceStringWith with masker for filters implementing
+// MaskerFilter
+func (x Filters) ReplaceStringWith(masker customMasker.MaskerInterface, s string) string {
+	for _, f := range x {
+		if maskerFilter, ok := f.(MaskerFilter); ok {
+			s = maskerFilter.ReplaceStringWith(masker, s)
+			continue
+		}
+		s = f.ReplaceString(s)
+	}
+	return s
+}
+
+// MaskStringWith calls MaskString of the filter, or MaskStringWith with masker if it implements MaskerFilter
+func MaskStringWith(f Filter, masker customMasker.MaskerInterface, s string) string {
+	if maskerFilter, ok := f.(MaskerFilter); ok {
+		return maskerFilter.MaskStringWith(masker, s)
+	}
+	return f.MaskString(s)
+}
+
 func (x Filters) ShouldMask(fieldName string, value interface{}, tag string) bool {
 	for _, f := range x {
 		if f.ShouldMask(fieldName, value, tag) {
@@ -50,10 +212,18 @@ func UpdateCustomMaskingChar(maskingCharacter customMasker.MaskingCharacter) {
 	customMaskerInstance.UpdateMaskingCharacter(maskingCharacter)
 }
 
+// matchBinder is implemented by filters whose masking depends on the value that matched them
+type matchBinder interface {
+	forMatch(fieldName string, value interface{}, tag string) Filter
+}
+
 // Internal function to check if filter should mask based on criterion and return the filter matching
 func CheckShouldMask(x Filters, fieldName string, value interface{}, tag string) (Filter, bool) {
 	for _, f := range x {
 		if f.ShouldMask(fieldName, value, tag) {
+			if b, ok := f.(matchBinder); ok {
+				return b.forMatch(fieldName, value, tag), true
+			}
 			return f, true
 		}
 	}
@@ -0,0 +1,81 @@
+package filter
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/anu1097/golang-masking-tool/customMasker"
+)
+
+// Placeholders of redaction labels
+const (
+	// LabelType is replaced with the mask type of the filter, empty if it has none
+	LabelType = "{type}"
+	// LabelFilter is replaced with the kind of filter: field, field_prefix, value, type, tag, all_fields, or the kind of
+	// data found by regex filters: phone, email, credit_card, taiwan_id, url, secret or regex
+	LabelFilter = "{filter}"
+	// LabelLen is replaced with the number of characters of the redacted value
+	LabelLen = "{len}"
+)
+
+var (
+	typeLabels   = map[customMasker.Mtype]string{}
+	typeLabelsMu sync.RWMutex
+)
+
+// SetTypeLabel makes filters redact values of the mask type with a label instead of masking them. Labels may contain
+// the placeholders {type}, {filter} and {len}.
+//
+// Example:
+//
+//	filter.SetTypeLabel(customMasker.MCreditCard, "<REDACTED type=card>")
+func SetTypeLabel(t customMasker.Mtype, label string) {
+	typeLabelsMu.Lock()
+	defer typeLabelsMu.Unlock()
+	typeLabels[t] = label
+}
+
+// GetTypeLabel returns the label redacting values of the mask type, if any
+func GetTypeLabel(t customMasker.Mtype) (string, bool) {
+	if t == "" {
+		return "", false
+	}
+	typeLabelsMu.RLock()
+	defer typeLabelsMu.RUnlock()
+	label, ok := typeLabels[t]
+	return label, ok
+}
+
+// RemoveTypeLabel makes filters mask values of the mask type again
+func RemoveTypeLabel(t customMasker.Mtype) {
+	typeLabelsMu.Lock()
+	defer typeLabelsMu.Unlock()
+	delete(typeLabels, t)
+}
+
+// WithLabel returns a copy of a filter created by this package with a redaction label attached. The copy redacts values
+// with the label instead of masking them. Labels may contain the placeholders {type}, {filter} and {len}. Other filters
+// are returned unchanged.
+//
+// Example:
+//
+//	filter.WithLabel(filter.EmailFilter(), "[filtered:{filter}:{len}]")
+func WithLabel(f Filter, label string) Filter {
+	return withBinding(f, func(b *maskerBinding) {
+		b.redactedLabel = &label
+	})
+}
+
+// RenderLabel replaces the placeholders of a redaction label
+func RenderLabel(label string, filterName string, maskType customMasker.Mtype, s string) string {
+	if !strings.Contains(label, "{") {
+		return label
+	}
+	return strings.NewReplacer(
+		LabelType, string(maskType),
+		LabelFilter, filterName,
+		LabelLen, strconv.Itoa(utf8.RuneCountInString(s)),
+	).Replace(label)
+}
@@ -7,16 +7,39 @@ import (
 )
 
 const defaultPhoneRegex = `^((\+\d{1,3}(-| )?\(?\d\)?(-| )?\d{1,5})|(\(?\d{2,6}\)?))(-| )?(\d{3,4})(-| )?(\d{4})(( x| ext)\d{1,5}){0,1}$`
+const defaultCreditCardRegex = `\b\d(?:[ -]?\d){12,18}\b`
+const defaultURLRegex = `\b[A-Za-z][A-Za-z0-9+.-]*://[^\s"'<>]*[^\s"'<>.,;:!?)\]]`
+const defaultTaiwanIDRegex = `\b[A-Za-z][1289A-Da-d]\d{8}\b`
+
+// defaultSecretRegexList matches PEM blocks, Authorization header values, key=value pairs of secret keys, JSON web
+// tokens and API keys of common providers
+var defaultSecretRegexList = []string{
+	`-----BEGIN [A-Z0-9 ]+-----[\s\S]*?-----END [A-Z0-9 ]+-----`,
+	`(?i)\b(?:bearer|basic)\s+[A-Za-z0-9._~+/-]{8,}=*`,
+	`(?i)\b[\w.-]*(?:password|passwd|pwd|secret|token|api_?key)\s*=\s*(?:"[^"]*"|'[^']*'|[^\s&;,]+)`,
+	`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`,
+	`\b(?:sk|pk|rk)_(?:live|test)_[A-Za-z0-9]{10,}\b`,
+	`\bgh[pousr]_[A-Za-z0-9]{36,}\b`,
+	`\bxox[abprs]-[A-Za-z0-9-]{10,}`,
+	`\bAKIA[0-9A-Z]{16}\b`,
+}
+
 const defaultEmailRegex = "^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$"
 
 type piiRegexFilter struct {
+	maskerBinding
 	RegexList []regexp.Regexp
 	mtype     customMasker.Mtype
+	// name is the {filter} placeholder of redaction labels
+	name string
+	// validate is called with each match, which is left unchanged if it returns false
+	validate func(match string) bool
 }
 
 // Get Phone Filter.
 func PhoneFilter() *piiRegexFilter {
 	return &piiRegexFilter{
+		name: "phone",
 		RegexList: []regexp.Regexp{
 			*regexp.MustCompile(defaultPhoneRegex),
 		},
@@ -26,6 +49,7 @@ func PhoneFilter() *piiRegexFilter {
 // Get Custom Phone Filter with custom masking type.
 func CustomPhoneFilter(mtype customMasker.Mtype) *piiRegexFilter {
 	return &piiRegexFilter{
+		name: "phone",
 		RegexList: []regexp.Regexp{
 			*regexp.MustCompile(defaultPhoneRegex),
 		},
@@ -36,6 +60,7 @@ func CustomPhoneFilter(mtype customMasker.Mtype) *piiRegexFilter {
 // Get Email Filter.
 func EmailFilter() *piiRegexFilter {
 	return &piiRegexFilter{
+		name: "email",
 		RegexList: []regexp.Regexp{
 			*regexp.MustCompile(defaultEmailRegex),
 		},
@@ -45,6 +70,7 @@ func EmailFilter() *piiRegexFilter {
 // Get Custom Email Filter with custom masking type.
 func CustomEmailFilter(mtype customMasker.Mtype) *piiRegexFilter {
 	return &piiRegexFilter{
+		name: "email",
 		RegexList: []regexp.Regexp{
 			*regexp.MustCompile(defaultEmailRegex),
 		},
@@ -52,18 +78,114 @@ func CustomEmailFilter(mtype customMasker.Mtype) *piiRegexFilter {
 	}
 }
 
+// Get Credit Card Filter. Only masks numbers of 13 to 19 digits with a valid Luhn check digit, each on its own.
+func CreditCardFilter() *piiRegexFilter {
+	return &piiRegexFilter{
+		maskerBinding: maskerBinding{maskMatches: true},
+		name:          "credit_card",
+		RegexList: []regexp.Regexp{
+			*regexp.MustCompile(defaultCreditCardRegex),
+		},
+		validate: customMasker.CardPolicy{ValidateLuhn: true}.IsCreditCard,
+	}
+}
+
+// Get Custom Credit Card Filter with custom masking type. Only masks numbers of 13 to 19 digits with a valid Luhn check
+// digit.
+func CustomCreditCardFilter(mtype customMasker.Mtype) *piiRegexFilter {
+	return &piiRegexFilter{
+		maskerBinding: maskerBinding{maskMatches: true},
+		name:          "credit_card",
+		RegexList: []regexp.Regexp{
+			*regexp.MustCompile(defaultCreditCardRegex),
+		},
+		mtype:    mtype,
+		validate: customMasker.CardPolicy{ValidateLuhn: true}.IsCreditCard,
+	}
+}
+
+// Get Taiwan ID Filter. Only masks national identification numbers and resident certificate numbers with a valid
+// region letter and checksum, each on its own.
+func TaiwanIDFilter() *piiRegexFilter {
+	return &piiRegexFilter{
+		maskerBinding: maskerBinding{maskMatches: true},
+		name:          "taiwan_id",
+		RegexList: []regexp.Regexp{
+			*regexp.MustCompile(defaultTaiwanIDRegex),
+		},
+		validate: customMasker.IsTaiwanID,
+	}
+}
+
+// Get Custom Taiwan ID Filter with custom masking type. Only masks national identification numbers and resident
+// certificate numbers with a valid region letter and checksum.
+func CustomTaiwanIDFilter(mtype customMasker.Mtype) *piiRegexFilter {
+	return &piiRegexFilter{
+		maskerBinding: maskerBinding{maskMatches: true},
+		name:          "taiwan_id",
+		RegexList: []regexp.Regexp{
+			*regexp.MustCompile(defaultTaiwanIDRegex),
+		},
+		mtype:    mtype,
+		validate: customMasker.IsTaiwanID,
+	}
+}
+
+// Get Secret Filter. Masks the secret-shaped values found in strings with the secret masker, which keeps a
+// fingerprint: PEM blocks, Bearer and Basic credentials, password, secret, token and API key pairs, JSON web tokens
+// and API keys of common providers.
+func SecretFilter() *piiRegexFilter {
+	return CustomSecretFilter(customMasker.MSecret)
+}
+
+// Get Custom Secret Filter with custom masking type. Each secret is masked on its own.
+func CustomSecretFilter(mtype customMasker.Mtype) *piiRegexFilter {
+	var regexList []regexp.Regexp
+	for _, pattern := range defaultSecretRegexList {
+		regexList = append(regexList, *regexp.MustCompile(pattern))
+	}
+	return &piiRegexFilter{
+		maskerBinding: maskerBinding{maskMatches: true},
+		name:          "secret",
+		RegexList:     regexList,
+		mtype:         mtype,
+	}
+}
+
+// Get URL Filter. Masks the URLs found in strings with the URL masker, which redacts passwords, sensitive query
+// parameters, path segments and fragments.
+func URLFilter() *piiRegexFilter {
+	return CustomURLFilter(customMasker.MURL)
+}
+
+// Get Custom URL Filter with custom masking type. Each URL is masked on its own.
+func CustomURLFilter(mtype customMasker.Mtype) *piiRegexFilter {
+	return &piiRegexFilter{
+		maskerBinding: maskerBinding{maskMatches: true},
+		name:          "url",
+		RegexList: []regexp.Regexp{
+			*regexp.MustCompile(defaultURLRegex),
+		},
+		mtype: mtype,
+	}
+}
+
 // Get Custom Regex Filter.
 func CustomRegexFilter(regexPattern string) *piiRegexFilter {
 	return &piiRegexFilter{
+		name: "regex",
 		RegexList: []regexp.Regexp{
 			*regexp.MustCompile(regexPattern),
 		},
 	}
 }
 
-// Get Custom Regex Filter with custom masking type
+// Get Custom Regex Filter with custom masking type. Matches are replaced with the masking of the whole string, use
+// MaskMatches to mask each match on its own. Hash, pseudonym, encryption, token and reference mask types always mask
+// each match on its own.
 func CustomRegexFilterWithMType(regexPattern string, mtype customMasker.Mtype) *piiRegexFilter {
 	return &piiRegexFilter{
+		name: "regex",
 		RegexList: []regexp.Regexp{
 			*regexp.MustCompile(regexPattern),
 		},
@@ -72,8 +194,18 @@ func CustomRegexFilterWithMType(regexPattern string, mtype customMasker.Mtype) *
 }
 
 func (x *piiRegexFilter) ReplaceString(s string) string {
+	return x.ReplaceStringWith(customMaskerInstance, s)
+}
+
+func (x *piiRegexFilter) ReplaceStringWith(masker customMasker.MaskerInterface, s string) string {
 	for _, p := range x.RegexList {
-		s = p.ReplaceAllString(s, customMaskerInstance.String(x.mtype, s, GetFilteredLabel()))
+		original := s
+		s = p.ReplaceAllStringFunc(s, func(match string) string {
+			if x.validate != nil && !x.validate(match) {
+				return match
+			}
+			return x.replacement(masker, x.name, x.mtype, original, match)
+		})
 	}
 	return s
 }
@@ -82,6 +214,19 @@ func (x *piiRegexFilter) MaskString(s string) string {
 	return s
 }
 
+func (x *piiRegexFilter) MaskStringWith(masker customMasker.MaskerInterface, s string) string {
+	return s
+}
+
 func (x *piiRegexFilter) ShouldMask(fieldName string, value interface{}, tag string) bool {
 	return false
 }
+
+func (x *piiRegexFilter) MaskTypes() []customMasker.Mtype {
+	return []customMasker.Mtype{x.mtype}
+}
+
+func (x *piiRegexFilter) copyFilter() (Filter, *maskerBinding) {
+	copied := *x
+	return &copied, &copied.maskerBinding
+}
@@ -3,6 +3,7 @@ package filter
 import "github.com/anu1097/golang-masking-tool/customMasker"
 
 type tagFilter struct {
+	maskerBinding
 	SecureTags []string
 	maskType   customMasker.Mtype
 }
@@ -17,11 +18,12 @@ func GetTagKey() string {
 	return tagKey
 }
 
-// Get Tag Filter. Need to pass custom masker type string.
+// Get Tag Filter. Need to pass custom masker type string. Without mask types, fields tagged `mask:"secret"` are masked
+// with MSecret, which keeps a fingerprint of the value.
 //
 // Example:
-//   input: secret
-//   output: [filtered]
+//   input: dummy@dummy.com
+//   output: dum****[4dfe78f4]
 func TagFilter(tags ...customMasker.Mtype) *tagFilter {
 	if len(tags) == 0 {
 		tags = []customMasker.Mtype{customMasker.MSecret}
@@ -37,16 +39,50 @@ func TagFilter(tags ...customMasker.Mtype) *tagFilter {
 
 func (x *tagFilter) ReplaceString(s string) string { return s }
 
+func (x *tagFilter) ReplaceStringWith(masker customMasker.MaskerInterface, s string) string {
+	return s
+}
+
 func (x *tagFilter) MaskString(s string) string {
-	return customMaskerInstance.String(x.maskType, s, GetFilteredLabel())
+	return x.MaskStringWith(customMaskerInstance, s)
+}
+
+func (x *tagFilter) MaskStringWith(masker customMasker.MaskerInterface, s string) string {
+	return x.mask(masker, "tag", x.maskType, s)
+}
+
+func (x *tagFilter) MaskValue(masker customMasker.MaskerInterface, value interface{}) (interface{}, bool) {
+	return x.maskValue(masker, x.maskType, value)
 }
 
 func (x *tagFilter) ShouldMask(fieldName string, value interface{}, tag string) bool {
 	for i := range x.SecureTags {
 		if x.SecureTags[i] == tag {
-			x.maskType = customMasker.Mtype(tag)
 			return true
 		}
 	}
 	return false
 }
+
+func (x *tagFilter) MaskTypes() []customMasker.Mtype {
+	var maskTypes []customMasker.Mtype
+	for _, tag := range x.SecureTags {
+		maskTypes = append(maskTypes, customMasker.Mtype(tag))
+	}
+	return maskTypes
+}
+
+// Returns a copy of the filter masking with the matched tag. Filters are shared by concurrent masking calls, so the
+// matched tag cannot be stored on the filter itself.
+func (x *tagFilter) forMatch(fieldName string, value interface{}, tag string) Filter {
+	return &tagFilter{
+		maskerBinding: x.maskerBinding,
+		SecureTags:    x.SecureTags,
+		maskType:      customMasker.Mtype(tag),
+	}
+}
+
+func (x *tagFilter) copyFilter() (Filter, *maskerBinding) {
+	copied := *x
+	return &copied, &copied.maskerBinding
+}
@@ -7,6 +7,7 @@ import (
 )
 
 type typeFilter struct {
+	maskerBinding
 	target   reflect.Type
 	maskType customMasker.Mtype
 }
@@ -28,10 +29,31 @@ func CustomTypeFilter(t interface{}, maskType customMasker.Mtype) *typeFilter {
 
 func (x *typeFilter) ReplaceString(s string) string { return s }
 
+func (x *typeFilter) ReplaceStringWith(masker customMasker.MaskerInterface, s string) string {
+	return s
+}
+
 func (x *typeFilter) MaskString(s string) string {
-	return customMaskerInstance.String(x.maskType, s, GetFilteredLabel())
+	return x.MaskStringWith(customMaskerInstance, s)
+}
+
+func (x *typeFilter) MaskStringWith(masker customMasker.MaskerInterface, s string) string {
+	return x.mask(masker, "type", x.maskType, s)
+}
+
+func (x *typeFilter) MaskValue(masker customMasker.MaskerInterface, value interface{}) (interface{}, bool) {
+	return x.maskValue(masker, x.maskType, value)
 }
 
 func (x *typeFilter) ShouldMask(fieldName string, value interface{}, tag string) bool {
 	return x.target == reflect.TypeOf(value)
 }
+
+func (x *typeFilter) MaskTypes() []customMasker.Mtype {
+	return []customMasker.Mtype{x.maskType}
+}
+
+func (x *typeFilter) copyFilter() (Filter, *maskerBinding) {
+	copied := *x
+	return &copied, &copied.maskerBinding
+}
@@ -8,6 +8,7 @@ import (
 
 // Get Value Filter.
 type valueFilter struct {
+	maskerBinding
 	target   string
 	maskType customMasker.Mtype
 }
@@ -19,6 +20,9 @@ func ValueFilter(target string) *valueFilter {
 	}
 }
 
+// Get Custom Value Filter with custom masking type. The target is replaced with the masking of the whole string
+// containing it, use MaskMatches to mask only the target. Hash, pseudonym, encryption, token and reference mask types
+// always mask only the target.
 func CustomValueFilter(target string, maskType customMasker.Mtype) *valueFilter {
 	return &valueFilter{
 		target:   target,
@@ -27,13 +31,33 @@ func CustomValueFilter(target string, maskType customMasker.Mtype) *valueFilter
 }
 
 func (x *valueFilter) ReplaceString(s string) string {
-	return strings.ReplaceAll(s, x.target, customMaskerInstance.String(x.maskType, s, GetFilteredLabel()))
+	return x.ReplaceStringWith(customMaskerInstance, s)
+}
+
+func (x *valueFilter) ReplaceStringWith(masker customMasker.MaskerInterface, s string) string {
+	if x.target == "" || !strings.Contains(s, x.target) {
+		return s
+	}
+	return strings.ReplaceAll(s, x.target, x.replacement(masker, "value", x.maskType, s, x.target))
 }
 
 func (x *valueFilter) MaskString(s string) string {
 	return s
 }
 
+func (x *valueFilter) MaskStringWith(masker customMasker.MaskerInterface, s string) string {
+	return s
+}
+
 func (x *valueFilter) ShouldMask(fieldName string, value interface{}, tag string) bool {
 	return false
 }
+
+func (x *valueFilter) MaskTypes() []customMasker.Mtype {
+	return []customMasker.Mtype{x.maskType}
+}
+
+func (x *valueFilter) copyFilter() (Filter, *maskerBinding) {
+	copied := *x
+	return &copied, &copied.maskerBinding
+}
@@ -1,7 +1,9 @@
 package mask
 
 import (
+	"fmt"
 	"reflect"
+	"sync"
 
 	"github.com/anu1097/golang-masking-tool/customMasker"
 	"github.com/anu1097/golang-masking-tool/filter"
@@ -20,6 +22,35 @@ type Masking interface {
 	// Call to update masking character for custom masker
 	UpdateCustomMaskingChar(maskingChar customMasker.MaskingCharacter)
 
+	// Call to update the masking character of a single mask type. Has no effect if the custom masker doesn't implement
+	// UpdateTypeMaskingCharacter
+	UpdateTypeMaskingChar(t customMasker.Mtype, maskingChar customMasker.MaskingCharacter)
+
+	// Call to update how masked characters of a single mask type are replaced, e.g. customMasker.SClassPreserving. Has
+	// no effect if the custom masker doesn't implement UpdateTypeMaskStyle
+	UpdateTypeMaskStyle(t customMasker.Mtype, style customMasker.MaskStyle)
+
+	// Call to replace the custom masker used by all filters of the masking instance
+	UpdateCustomMasker(masker customMasker.MaskerInterface)
+
+	// Call to get the custom masker used by all filters of the masking instance
+	GetCustomMasker() customMasker.MaskerInterface
+
+	// Call to register a user-defined mask type for the custom masker of the masking instance
+	RegisterMaskType(t customMasker.Mtype, fn func(i string) string) error
+
+	// Call to take the keys of encryption, pseudonyms and tokens of the custom masker from a key provider, e.g. a
+	// customMasker.FileKeyring. Returns an error if the custom masker doesn't support key providers
+	UseKeyProvider(provider customMasker.KeyProvider) error
+
+	// Call to share a session between masking instances, so identifiers masked with customMasker.MReference, or with
+	// mask types registered with RegisterReference, get the same reference in all of them. Returns an error if the
+	// custom masker doesn't support sessions
+	UseSession(session *customMasker.Session) error
+
+	// Call to check that every mask type used by the filters is built-in or registered
+	ValidateFilters() error
+
 	// Call to update filter label
 	UpdateFilterLabel(filterlabel string)
 
@@ -35,12 +66,23 @@ type Masking interface {
 	// Call to Mask Details from a given instance
 	MaskDetails(v interface{}) interface{}
 
+	// Call to restore the encrypted values of a copy returned by MaskDetails with the keys of the custom masker.
+	// Returns customMasker.ErrUnknownKeyID or customMasker.ErrTamperedValue if a value can't be decrypted
+	Unmask(v interface{}) (interface{}, error)
+
+	// Call to mask elements of slices, arrays and maps concurrently using at most workers goroutines.
+	// Collections with fewer than minItems elements are always masked sequentially. Pass workers <= 1 to disable.
+	UpdateParallelism(workers int, minItems int)
+
 	// Internal function which masks based on filters and returns a clone of the data passed
-	clone(fieldName string, value reflect.Value, tag string) reflect.Value
+	clone(fieldName string, value reflect.Value, tag string, pool *workerPool) reflect.Value
 }
 
 type masking struct {
-	filterList filter.Filters
+	filterList       filter.Filters
+	masker           customMasker.MaskerInterface
+	workers          int
+	minParallelItems int
 }
 
 // Get a pointer to new masking instance. Pass your required filters
@@ -49,16 +91,40 @@ type masking struct {
 //
 //	var maskingInstance = NewMaskingInstance(filter.FieldFilter("Phone"))
 func NewMaskingInstance(filters ...filter.Filter) *masking {
-	filter.SetCustomMaskerInstance(customMasker.NewMasker())
-	var filterList = filter.Filters{}
-	filterList = append(filterList, filters...)
-	return &masking{
-		filterList: filterList,
+	x := &masking{
+		filterList: filter.Filters{},
+		masker:     customMasker.NewMasker(),
 	}
+	x.AppendFilters(filters...)
+	return x
 }
 
 func (x *masking) UpdateCustomMaskingChar(maskingChar customMasker.MaskingCharacter) {
-	filter.UpdateCustomMaskingChar(maskingChar)
+	x.masker.UpdateMaskingCharacter(maskingChar)
+}
+
+func (x *masking) UpdateTypeMaskingChar(t customMasker.Mtype, maskingChar customMasker.MaskingCharacter) {
+	if masker, ok := x.masker.(interface {
+		UpdateTypeMaskingCharacter(t customMasker.Mtype, maskingCharacter customMasker.MaskingCharacter)
+	}); ok {
+		masker.UpdateTypeMaskingCharacter(t, maskingChar)
+	}
+}
+
+func (x *masking) UpdateTypeMaskStyle(t customMasker.Mtype, style customMasker.MaskStyle) {
+	if masker, ok := x.masker.(interface {
+		UpdateTypeMaskStyle(t customMasker.Mtype, style customMasker.MaskStyle)
+	}); ok {
+		masker.UpdateTypeMaskStyle(t, style)
+	}
+}
+
+func (x *masking) UpdateCustomMasker(masker customMasker.MaskerInterface) {
+	x.masker = masker
+}
+
+func (x *masking) GetCustomMasker() customMasker.MaskerInterface {
+	return x.masker
 }
 
 func (x *masking) UpdateFilterLabel(filterlabel string) {
@@ -69,6 +135,58 @@ func (x *masking) GetFilterLabel() string {
 	return filter.GetFilteredLabel()
 }
 
+func (x *masking) RegisterMaskType(t customMasker.Mtype, fn func(i string) string) error {
+	registry, ok := x.masker.(interface {
+		RegisterMaskType(t customMasker.Mtype, fn func(i string) string) error
+	})
+	if !ok {
+		return fmt.Errorf("custom masker %T does not support registering mask types", x.masker)
+	}
+	return registry.RegisterMaskType(t, fn)
+}
+
+func (x *masking) UseKeyProvider(provider customMasker.KeyProvider) error {
+	keyed, ok := x.masker.(interface {
+		UseKeyProvider(provider customMasker.KeyProvider) error
+	})
+	if !ok {
+		return fmt.Errorf("custom masker %T does not support key providers", x.masker)
+	}
+	return keyed.UseKeyProvider(provider)
+}
+
+func (x *masking) UseSession(session *customMasker.Session) error {
+	sessionUser, ok := x.masker.(interface {
+		UseSession(session *customMasker.Session)
+	})
+	if !ok {
+		return fmt.Errorf("custom masker %T does not support sessions", x.masker)
+	}
+	sessionUser.UseSession(session)
+	return nil
+}
+
+func (x *masking) ValidateFilters() error {
+	validate := customMasker.ValidateMaskType
+	if validator, ok := x.masker.(interface {
+		ValidateMaskType(t customMasker.Mtype) error
+	}); ok {
+		validate = validator.ValidateMaskType
+	}
+	for _, f := range x.filterList {
+		typer, ok := f.(filter.MaskTyper)
+		if !ok {
+			continue
+		}
+		for _, t := range typer.MaskTypes() {
+			if err := validate(t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (x *masking) AppendFilters(filters ...filter.Filter) {
 	x.filterList = append(x.filterList, filters...)
 }
@@ -77,14 +195,20 @@ func (x *masking) GetFilters() filter.Filters {
 	return x.filterList
 }
 
+func (x *masking) UpdateParallelism(workers int, minItems int) {
+	x.workers = workers
+	x.minParallelItems = minItems
+}
+
 func (x *masking) MaskDetails(v interface{}) interface{} {
 	if v == nil {
 		return nil
 	}
-	return x.clone("", reflect.ValueOf(v), "").Interface()
+	pool := newWorkerPool(x.workers, x.minParallelItems)
+	return x.clone("", reflect.ValueOf(v), "", pool).Interface()
 }
 
-func (x *masking) clone(fieldName string, value reflect.Value, tag string) reflect.Value {
+func (x *masking) clone(fieldName string, value reflect.Value, tag string, pool *workerPool) reflect.Value {
 	adjustValue := func(ret reflect.Value) reflect.Value {
 		switch value.Kind() {
 		case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Array:
@@ -108,10 +232,18 @@ func (x *masking) clone(fieldName string, value reflect.Value, tag string) refle
 		dst = reflect.New(src.Type())
 		switch src.Kind() {
 		case reflect.String:
-			filteredData := maskingFilter.MaskString(value.String())
+			filteredData := filter.MaskStringWith(maskingFilter, x.masker, value.String())
 			dst.Elem().SetString(filteredData)
 		case reflect.Array, reflect.Slice:
 			dst = dst.Elem()
+		default:
+			if valueMasker, ok := maskingFilter.(filter.ValueMasker); ok {
+				if masked, ok := valueMasker.MaskValue(x.masker, src.Interface()); ok {
+					if maskedValue := reflect.ValueOf(masked); maskedValue.IsValid() && maskedValue.Type().ConvertibleTo(src.Type()) {
+						dst.Elem().Set(maskedValue.Convert(src.Type()))
+					}
+				}
+			}
 		}
 		return adjustValue(dst)
 	}
@@ -119,7 +251,7 @@ func (x *masking) clone(fieldName string, value reflect.Value, tag string) refle
 	switch src.Kind() {
 	case reflect.String:
 		dst = reflect.New(src.Type())
-		filtered := x.filterList.ReplaceString(value.String())
+		filtered := x.filterList.ReplaceStringWith(x.masker, value.String())
 		dst.Elem().SetString(filtered)
 
 	case reflect.Struct:
@@ -134,20 +266,24 @@ func (x *masking) clone(fieldName string, value reflect.Value, tag string) refle
 			}
 			tagValue := f.Tag.Get(filter.GetTagKey())
 			if fv.Type().Kind() == reflect.Ptr && fv.Elem().Kind() == reflect.String {
-				a := x.clone(f.Name, fv.Elem(), tagValue).Convert(reflect.TypeOf("")).Interface().(string)
+				a := x.clone(f.Name, fv.Elem(), tagValue, pool).Convert(reflect.TypeOf("")).Interface().(string)
 				dst.Elem().Field(i).Set(reflect.New(fv.Elem().Type()))
 				dst.Elem().Field(i).Elem().SetString(a)
 			} else {
-				dst.Elem().Field(i).Set(x.clone(f.Name, fv, tagValue))
+				dst.Elem().Field(i).Set(x.clone(f.Name, fv, tagValue, pool))
 			}
 		}
 
 	case reflect.Map:
 		dst = reflect.MakeMap(src.Type())
 		keys := src.MapKeys()
-		for i := 0; i < src.Len(); i++ {
-			mValue := src.MapIndex(keys[i])
-			dst.SetMapIndex(keys[i], x.clone(keys[i].String(), mValue, ""))
+		values := make([]reflect.Value, len(keys))
+		pool.forEach(len(keys), func(i int) {
+			values[i] = x.clone(keys[i].String(), src.MapIndex(keys[i]), "", pool)
+		})
+		// reflect maps are not safe for concurrent writes, so entries are set once all values are masked
+		for i := range keys {
+			dst.SetMapIndex(keys[i], values[i])
 		}
 
 	case reflect.Array, reflect.Slice:
@@ -156,9 +292,9 @@ func (x *masking) clone(fieldName string, value reflect.Value, tag string) refle
 		} else {
 			dst = reflect.MakeSlice(src.Type(), src.Len(), src.Cap())
 		}
-		for i := 0; i < src.Len(); i++ {
-			dst.Index(i).Set(x.clone(fieldName, src.Index(i), ""))
-		}
+		pool.forEach(src.Len(), func(i int) {
+			dst.Index(i).Set(x.clone(fieldName, src.Index(i), "", pool))
+		})
 
 	case reflect.Interface:
 		dst = reflect.New(src.Type())
@@ -167,7 +303,7 @@ func (x *masking) clone(fieldName string, value reflect.Value, tag string) refle
 		if !ok {
 			dst.Elem().Set(src)
 		} else {
-			filtered := x.filterList.ReplaceString(stringData)
+			filtered := x.filterList.ReplaceStringWith(x.masker, stringData)
 			dst.Elem().Set(reflect.ValueOf(filtered))
 		}
 
@@ -177,3 +313,75 @@ func (x *masking) clone(fieldName string, value reflect.Value, tag string) refle
 	}
 	return adjustValue(dst)
 }
+
+// workerPool bounds the number of goroutines a single MaskDetails call may use.
+// It is shared by nested collections, so the bound holds for the whole value being masked.
+type workerPool struct {
+	tokens   chan struct{}
+	minItems int
+}
+
+func newWorkerPool(workers int, minItems int) *workerPool {
+	if workers <= 1 {
+		return nil
+	}
+	return &workerPool{
+		tokens:   make(chan struct{}, workers-1),
+		minItems: minItems,
+	}
+}
+
+// forEach calls fn for every index in [0, n). Indices are split in contiguous chunks which are handed to idle
+// workers; when none is free the calling goroutine processes the chunk itself, so nested collections never wait
+// on each other. fn must only write to state owned by index i, which keeps the output in the original order.
+func (p *workerPool) forEach(n int, fn func(i int)) {
+	if p == nil || n < p.minItems || n < 2 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	chunkSize := n / (4 * (cap(p.tokens) + 1))
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var recovered interface{}
+	run := func(start, end int) {
+		for i := start; i < end; i++ {
+			fn(i)
+		}
+	}
+
+	for start := 0; start < n; start += chunkSize {
+		end := start + chunkSize
+		if end > n {
+			end = n
+		}
+		select {
+		case p.tokens <- struct{}{}:
+			wg.Add(1)
+			go func(start, end int) {
+				defer func() {
+					if r := recover(); r != nil {
+						once.Do(func() { recovered = r })
+					}
+					<-p.tokens
+					wg.Done()
+				}()
+				run(start, end)
+			}(start, end)
+		default:
+			run(start, end)
+		}
+	}
+	wg.Wait()
+
+	// surface panics from workers on the calling goroutine, as the sequential path would
+	if recovered != nil {
+		panic(recovered)
+	}
+}
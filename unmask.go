@@ -0,0 +1,116 @@
+package mask
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrUnmaskNotSupported is returned by Unmask when the custom masker of the masking instance can't decrypt values
+var ErrUnmaskNotSupported = errors.New("custom masker doesn't support unmasking")
+
+// unmasker is implemented by custom maskers which can restore the originals of encrypted values
+type unmasker interface {
+	Unmask(i string) (string, error)
+}
+
+func (x *masking) Unmask(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	u, ok := x.masker.(unmasker)
+	if !ok {
+		return nil, ErrUnmaskNotSupported
+	}
+	unmasked, err := unmaskValue(reflect.ValueOf(v), u)
+	if err != nil {
+		return nil, err
+	}
+	return unmasked.Interface(), nil
+}
+
+// unmaskValue returns a copy of the value where the strings are unmasked, with the same shape as the copies made by
+// clone
+func unmaskValue(value reflect.Value, u unmasker) (reflect.Value, error) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return reflect.New(value.Type()).Elem(), nil
+		}
+		elem, err := unmaskValue(value.Elem(), u)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		dst := reflect.New(value.Type().Elem())
+		dst.Elem().Set(elem)
+		return dst, nil
+
+	case reflect.String:
+		unmasked, err := u.Unmask(value.String())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(unmasked).Convert(value.Type()), nil
+
+	case reflect.Struct:
+		dst := reflect.New(value.Type()).Elem()
+		for i := 0; i < value.NumField(); i++ {
+			fv := value.Field(i)
+			if !fv.CanInterface() {
+				continue
+			}
+			unmasked, err := unmaskValue(fv, u)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			dst.Field(i).Set(unmasked)
+		}
+		return dst, nil
+
+	case reflect.Map:
+		if value.IsNil() {
+			return value, nil
+		}
+		dst := reflect.MakeMapWithSize(value.Type(), value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			unmasked, err := unmaskValue(iter.Value(), u)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			dst.SetMapIndex(iter.Key(), unmasked)
+		}
+		return dst, nil
+
+	case reflect.Array, reflect.Slice:
+		var dst reflect.Value
+		if value.Kind() == reflect.Array {
+			dst = reflect.New(value.Type()).Elem()
+		} else {
+			if value.IsNil() {
+				return value, nil
+			}
+			dst = reflect.MakeSlice(value.Type(), value.Len(), value.Cap())
+		}
+		for i := 0; i < value.Len(); i++ {
+			unmasked, err := unmaskValue(value.Index(i), u)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			dst.Index(i).Set(unmasked)
+		}
+		return dst, nil
+
+	case reflect.Interface:
+		if value.IsNil() {
+			return value, nil
+		}
+		unmasked, err := unmaskValue(value.Elem(), u)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		dst := reflect.New(value.Type()).Elem()
+		dst.Set(unmasked)
+		return dst, nil
+	}
+	return value, nil
+}